@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	healthgo "github.com/hellofresh/health-go/v5"
@@ -150,45 +151,68 @@ func main() {
 		return
 	}
 
-	subscriber, err := settings.Watermill.Broker.NewSubscriber()
+	eventPublisher, err := settings.Watermill.Broker.NewEventPublisher()
 	if err != nil {
-		slog.ErrorContext(ctx, "failed to create subscriber", slog.Any("err", err))
+		slog.ErrorContext(ctx, "failed to create event publisher", slog.Any("err", err))
 		return
 	}
 
+	eventSubscriber, err := settings.Watermill.Broker.NewEventSubscriber()
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to create event subscriber", slog.Any("err", err))
+		return
+	}
+	defer func() {
+		// Removes the event queue of this replica, which would otherwise outlive it
+		if err := eventSubscriber.Close(); err != nil {
+			slog.ErrorContext(ctx, "failed to close event subscriber", slog.Any("err", err))
+		}
+	}()
+
 	router := http.NewRouter(&settings.HTTP, &settings.App)
 	prefixedGroup := router.GetGroup()
 
 	// Create adapters
-	transformerFactory := imageProcessor.NewTransformerFactory(
-		imageProcessor.NewVipsResizeTransformer(),
-		imageProcessor.NewVipsGrayscaleTransformer(),
-		imageProcessor.NewVipsTrimTransformer(),
-		imageProcessor.NewVipsBlurTransformer(),
-		imageProcessor.NewVipsRotateTransformer(),
-	)
-	pipelineProcessor := imageProcessor.NewPipeline(transformerFactory)
 	objectStorerAdapter := objectStorer.NewMinioObjectStorer(minioClient)
 	imageRepository := postgres.NewPostgresImageRepository(pgxpool)
+	transformerRegistry, err := imageProcessor.NewVipsTransformerRegistry(imageProcessor.TransformerDependencies{
+		ObjectStorer:    objectStorerAdapter,
+		ImageRepository: imageRepository,
+		BucketName:      settings.ImageProcessor.BucketName,
+		FontsDir:        settings.ImageProcessor.FontsDir,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to register image transformers", slog.Any("err", err))
+		return
+	}
+	pipelineProcessor := imageProcessor.NewPipeline(transformerRegistry)
+	imageInspector := imageProcessor.NewVipsImageInspector()
 	metadataRepository := dynamodbAdapter.NewDynamoDBImageMetadataRepository(dynamoClient, settings.DynamoDB.Table)
 
 	// Create usecases
 	imageUseCase := application.NewImageUseCase(
 		publisher,
-		subscriber,
+		eventPublisher,
+		eventSubscriber,
 		imageRepository,
 		metadataRepository,
 		pipelineProcessor,
+		imageInspector,
 		objectStorerAdapter,
 		settings.ImageProcessor.BucketName,
 		settings.Watermill.ImageTopic,
+		settings.Watermill.ImageStatusTopic,
+		time.Duration(settings.ImageProcessor.PresignedURLExpiryInSec)*time.Second,
+		settings.ImageProcessor.RenderSigningKey,
 	)
 
 	// Register handlers
 	healthHandler := http.NewHealthHandler(health)
 	healthHandler.RegisterRoute(prefixedGroup)
-	imageHandler := http.NewImageHandler(imageUseCase)
+	imageHandler := http.NewImageHandler(imageUseCase, &settings.ImageProcessor)
 	imageHandler.RegisterRoute(prefixedGroup)
+	transformationHandler := http.NewTransformationHandler(transformerRegistry)
+	transformationHandler.RegisterRoute(prefixedGroup)
 
 	// Register Swagger UI (conditionally based on settings)
 	router.RegisterSwagger()
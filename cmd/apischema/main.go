@@ -0,0 +1,69 @@
+// apischema writes the OpenAPI schemas of the transformations into the API spec, from the config schemas
+// the transformers register with, so the spec and the API types generated from it follow the registry.
+// The aliases of the transformations, which the spec maps a single name to, and the keys the arguments of
+// render ops fill in are written to the images package, which resolves aliases and parses ops strings.
+// It runs before oapi-codegen in the openapi:generate task.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	imageProcessor "github.com/taldoflemis/sora-henkan/internal/infra/adapter/image_processor"
+)
+
+func main() {
+	var specPath, aliasesPath, renderArgsPath string
+
+	flag.StringVar(&specPath, "spec", "docs/openapi.yaml", "OpenAPI spec the transformation schemas are written into")
+	flag.StringVar(&aliasesPath, "aliases", "internal/core/domain/images/transformation_aliases.go", "Go file the transformation aliases are written to")
+	flag.StringVar(&renderArgsPath, "render-args", "internal/core/domain/images/render_args.go", "Go file the render op arguments are written to")
+	flag.Parse()
+
+	// The schemas don't depend on what the transformers are wired to
+	registry, err := imageProcessor.NewVipsTransformerRegistry(imageProcessor.TransformerDependencies{})
+	if err != nil {
+		log.Fatalf("Failed to create transformer registry: %v", err)
+	}
+
+	generated, err := transformationSchemas(registry.Registrations())
+	if err != nil {
+		log.Fatalf("Failed to generate transformation schemas: %v", err)
+	}
+
+	spec, err := os.ReadFile(specPath)
+	if err != nil {
+		log.Fatalf("Failed to read spec: %v", err)
+	}
+
+	updated, err := replaceGenerated(string(spec), generated)
+	if err != nil {
+		log.Fatalf("Failed to update spec: %v", err)
+	}
+
+	if err := os.WriteFile(specPath, []byte(updated), 0o644); err != nil {
+		log.Fatalf("Failed to write spec: %v", err)
+	}
+
+	aliases, err := transformationAliases(registry.Registrations())
+	if err != nil {
+		log.Fatalf("Failed to generate transformation aliases: %v", err)
+	}
+
+	if err := os.WriteFile(aliasesPath, aliases, 0o644); err != nil {
+		log.Fatalf("Failed to write transformation aliases: %v", err)
+	}
+
+	args, err := renderArgs(registry.Registrations())
+	if err != nil {
+		log.Fatalf("Failed to generate render op arguments: %v", err)
+	}
+
+	if err := os.WriteFile(renderArgsPath, args, 0o644); err != nil {
+		log.Fatalf("Failed to write render op arguments: %v", err)
+	}
+
+	log.Printf("Wrote %d transformation schemas to %s, their aliases to %s and their render op arguments to %s",
+		len(registry.Registrations()), specPath, aliasesPath, renderArgsPath)
+}
@@ -0,0 +1,347 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/taldoflemis/sora-henkan/internal/core/domain/images"
+	"github.com/taldoflemis/sora-henkan/internal/core/ports"
+)
+
+const (
+	// generatedBegin and generatedEnd surround the generated schemas in the spec
+	generatedBegin = "    # BEGIN transformation schemas, generated by cmd/apischema from the transformer registry, do not edit"
+	generatedEnd   = "    # END transformation schemas"
+
+	// schemaIndent is where the schemas are indented to under components.schemas
+	schemaIndent = 4
+)
+
+// keywordOrder is the order schema keywords are written in, keywords not listed come after in alphabetical order
+var keywordOrder = []string{
+	"type", "format", "description", "required", "enum", "default",
+	"minimum", "exclusiveMinimum", "maximum", "exclusiveMaximum",
+	"minLength", "maxLength", "minItems", "maxItems", "pattern",
+	"properties", "items", "anyOf", "discriminator", "propertyName", "mapping", "example",
+}
+
+// plainScalar matches strings YAML reads back as the same string without quotes
+var plainScalar = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_ ,./-]*[A-Za-z0-9_./]$|^[A-Za-z_]$`)
+
+// transformationSchemas renders TransformationRequest, a union of the transformations discriminated by name,
+// and a <Name>Transformation and <Name>Config schema for every registered transformation
+func transformationSchemas(registrations []ports.TransformerRegistration) (string, error) {
+	var out strings.Builder
+
+	union := []any{}
+	mapping := map[string]any{}
+	for _, registration := range registrations {
+		ref := schemaRef(schemaName(registration) + "Transformation")
+		union = append(union, map[string]any{"$ref": ref})
+		// oapi-codegen keeps a single name per schema, aliases are mapped to it by transformationAliases
+		mapping[registration.Transformer.Name()] = ref
+	}
+
+	writeSchema(&out, "TransformationRequest", map[string]any{
+		"description": "A pipeline step, the transformation called name applied with config",
+		"anyOf":       union,
+		"discriminator": map[string]any{
+			"propertyName": "name",
+			"mapping":      mapping,
+		},
+	})
+
+	for _, registration := range registrations {
+		name := schemaName(registration)
+
+		transformation := map[string]any{
+			"type":        "object",
+			"description": registration.Description,
+			"required":    []any{"name", "config"},
+			"properties": map[string]any{
+				"name": map[string]any{
+					"type": "string",
+					"enum": stringsToAny(registrationNames(registration)),
+				},
+				"config": map[string]any{"$ref": schemaRef(name + "Config")},
+			},
+		}
+
+		if len(registration.Examples) > 0 {
+			example, err := json.Marshal(map[string]any{
+				"name":   registration.Transformer.Name(),
+				"config": registration.Examples[0],
+			})
+			if err != nil {
+				return "", fmt.Errorf("failed to encode the example of %s: %w", registration.Transformer.Name(), err)
+			}
+			transformation["example"] = json.RawMessage(example)
+		}
+
+		writeSchema(&out, name+"Transformation", transformation)
+		writeSchema(&out, name+"Config", openAPISchema(registration.ConfigSchema))
+	}
+
+	return strings.TrimSuffix(out.String(), "\n"), nil
+}
+
+// transformationAliases renders the Go source of a map from the aliases of transformations to their names,
+// which the images package resolves aliases with, for the API to tell the transformations apart and for
+// equivalent render ops to share a cache key
+func transformationAliases(registrations []ports.TransformerRegistration) ([]byte, error) {
+	var out strings.Builder
+
+	out.WriteString("// Code generated by cmd/apischema from the transformer registry. DO NOT EDIT.\n\n")
+	out.WriteString("package images\n\n")
+	out.WriteString("// transformationAliases maps the aliases of transformations to their names\n")
+	out.WriteString("var transformationAliases = map[string]string{\n")
+	for _, registration := range registrations {
+		for _, alias := range registration.Aliases {
+			fmt.Fprintf(&out, "\t%q: %q,\n", alias, registration.Transformer.Name())
+		}
+	}
+	out.WriteString("}\n")
+
+	return format.Source([]byte(out.String()))
+}
+
+// renderArgs renders the Go source of a map from the names and aliases of transformations to the config keys
+// the arguments of their render ops fill in, which the images package parses ops strings with
+func renderArgs(registrations []ports.TransformerRegistration) ([]byte, error) {
+	args := map[string][]string{}
+	for _, registration := range registrations {
+		for _, name := range registrationNames(registration) {
+			keys := registration.RenderArgs
+			if aliasKeys, ok := registration.AliasRenderArgs[name]; ok {
+				keys = aliasKeys
+			}
+			if len(keys) > 0 {
+				args[name] = keys
+			}
+		}
+	}
+
+	var out strings.Builder
+
+	out.WriteString("// Code generated by cmd/apischema from the transformer registry. DO NOT EDIT.\n\n")
+	out.WriteString("package images\n\n")
+	out.WriteString("// renderOpPositionalArgs maps the arguments given without a key to config keys, in order.\n")
+	out.WriteString("// Every op also accepts key=value arguments, e.g. blur:sigma=2.\n")
+	out.WriteString("var renderOpPositionalArgs = map[string][]string{\n")
+	for _, name := range slices.Sorted(maps.Keys(args)) {
+		keys := make([]string, 0, len(args[name]))
+		for _, key := range args[name] {
+			// The size is referred to by its constant, so a rename stays in step with the parser
+			if key == images.RenderArgSize {
+				keys = append(keys, "RenderArgSize")
+				continue
+			}
+			keys = append(keys, strconv.Quote(key))
+		}
+		fmt.Fprintf(&out, "\t%q: {%s},\n", name, strings.Join(keys, ", "))
+	}
+	out.WriteString("}\n")
+
+	return format.Source([]byte(out.String()))
+}
+
+// replaceGenerated puts generated between the markers in spec, replacing what was generated before
+func replaceGenerated(spec string, generated string) (string, error) {
+	begin := strings.Index(spec, generatedBegin+"\n")
+	end := strings.Index(spec, generatedEnd+"\n")
+	if begin < 0 || end < begin {
+		return "", fmt.Errorf("spec has no %q and %q markers", strings.TrimSpace(generatedBegin), strings.TrimSpace(generatedEnd))
+	}
+
+	return spec[:begin+len(generatedBegin)+1] + generated + "\n" + spec[end:], nil
+}
+
+// openAPISchema converts the JSON Schema a transformer registers its config with to an OpenAPI 3.0 schema,
+// which has boolean exclusive bounds, no const, and needs a format for numbers to be generated as float64
+func openAPISchema(schema map[string]any) map[string]any {
+	converted := map[string]any{}
+
+	for keyword, value := range schema {
+		switch keyword {
+		case "exclusiveMinimum":
+			converted["minimum"] = value
+			converted["exclusiveMinimum"] = true
+		case "exclusiveMaximum":
+			converted["maximum"] = value
+			converted["exclusiveMaximum"] = true
+		case "const":
+			converted["enum"] = []any{value}
+		case "items":
+			converted["items"] = openAPISchema(value.(map[string]any))
+		case "properties":
+			properties := map[string]any{}
+			for name, property := range value.(map[string]any) {
+				properties[name] = openAPISchema(property.(map[string]any))
+			}
+			converted["properties"] = properties
+		case "anyOf":
+			// Every alternative is a value of the property type, constrained differently
+			alternatives := []any{}
+			for _, alternative := range value.([]any) {
+				alternativeSchema := openAPISchema(alternative.(map[string]any))
+				if schema["type"] != nil {
+					alternativeSchema["type"] = schema["type"]
+				}
+				alternatives = append(alternatives, alternativeSchema)
+			}
+			converted["anyOf"] = alternatives
+		default:
+			converted[keyword] = value
+		}
+	}
+
+	if converted["type"] == "number" && converted["format"] == nil {
+		converted["format"] = "double"
+	}
+
+	return converted
+}
+
+// writeSchema writes a named schema under components.schemas, followed by a blank line
+func writeSchema(out *strings.Builder, name string, schema map[string]any) {
+	fmt.Fprintf(out, "%s%s:\n", strings.Repeat(" ", schemaIndent), name)
+	writeMapping(out, schema, schemaIndent+2, false)
+	out.WriteString("\n")
+}
+
+// writeMapping writes the keywords of a schema in keywordOrder,
+// and the names of properties and discriminator values, which aren't keywords, in alphabetical order
+func writeMapping(out *strings.Builder, mapping map[string]any, indent int, names bool) {
+	prefix := strings.Repeat(" ", indent)
+
+	keys := slices.Sorted(maps.Keys(mapping))
+	if !names {
+		slices.SortStableFunc(keys, func(a, b string) int {
+			return keywordRank(a) - keywordRank(b)
+		})
+	}
+
+	for _, key := range keys {
+		switch value := mapping[key].(type) {
+		case map[string]any:
+			if len(value) == 0 {
+				fmt.Fprintf(out, "%s%s: {}\n", prefix, key)
+				continue
+			}
+			fmt.Fprintf(out, "%s%s:\n", prefix, key)
+			writeMapping(out, value, indent+2, !names && (key == "properties" || key == "mapping"))
+		case []any:
+			writeSequence(out, key, value, indent)
+		case []string:
+			writeSequence(out, key, stringsToAny(value), indent)
+		case json.RawMessage:
+			fmt.Fprintf(out, "%s%s: %s\n", prefix, key, value)
+		default:
+			fmt.Fprintf(out, "%s%s: %s\n", prefix, key, scalar(value))
+		}
+	}
+}
+
+// writeSequence writes lists of schemas and required properties as blocks, and other lists of scalars on one line
+func writeSequence(out *strings.Builder, key string, values []any, indent int) {
+	prefix := strings.Repeat(" ", indent)
+
+	if key == "required" || key == "anyOf" {
+		fmt.Fprintf(out, "%s%s:\n", prefix, key)
+		for _, value := range values {
+			if schema, ok := value.(map[string]any); ok {
+				var item strings.Builder
+				writeMapping(&item, schema, indent+4, false)
+				fmt.Fprintf(out, "%s  - %s", prefix, strings.TrimPrefix(item.String(), prefix+"    "))
+				continue
+			}
+			fmt.Fprintf(out, "%s  - %s\n", prefix, scalar(value))
+		}
+		return
+	}
+
+	items := make([]string, 0, len(values))
+	for _, value := range values {
+		item := scalar(value)
+		// Commas separate the items of a flow sequence, so strings with one are quoted
+		if text, ok := value.(string); ok && strings.Contains(item, ",") && !strings.HasPrefix(item, `"`) {
+			quoted, _ := json.Marshal(text)
+			item = string(quoted)
+		}
+		items = append(items, item)
+	}
+	fmt.Fprintf(out, "%s%s: [%s]\n", prefix, key, strings.Join(items, ", "))
+}
+
+// scalar writes a value on its own, quoting strings YAML would read as something else
+func scalar(value any) string {
+	switch value := value.(type) {
+	case string:
+		if plainScalar.MatchString(value) && !isYAMLKeyword(value) {
+			return value
+		}
+		if strings.HasPrefix(value, "#/") {
+			return "'" + value + "'"
+		}
+		quoted, _ := json.Marshal(value)
+		return string(quoted)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(value), 'f', -1, 32)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// isYAMLKeyword reports whether YAML reads a plain string as a boolean or null
+func isYAMLKeyword(value string) bool {
+	switch strings.ToLower(value) {
+	case "true", "false", "yes", "no", "on", "off", "y", "n", "null", "~":
+		return true
+	}
+	return false
+}
+
+// keywordRank is the position of a keyword in keywordOrder, after every listed keyword when it isn't listed
+func keywordRank(keyword string) int {
+	if rank := slices.Index(keywordOrder, keyword); rank >= 0 {
+		return rank
+	}
+	return len(keywordOrder)
+}
+
+// schemaName turns a transformation name such as auto_orient into the AutoOrient the schemas of it are prefixed with
+func schemaName(registration ports.TransformerRegistration) string {
+	var name strings.Builder
+	for _, word := range strings.Split(registration.Transformer.Name(), "_") {
+		if word == "" {
+			continue
+		}
+		name.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return name.String()
+}
+
+// registrationNames lists the name of a transformation followed by its aliases
+func registrationNames(registration ports.TransformerRegistration) []string {
+	return append([]string{registration.Transformer.Name()}, registration.Aliases...)
+}
+
+func schemaRef(name string) string {
+	return "#/components/schemas/" + name
+}
+
+func stringsToAny(values []string) []any {
+	converted := make([]any, 0, len(values))
+	for _, value := range values {
+		converted = append(converted, value)
+	}
+	return converted
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/taldoflemis/sora-henkan/internal/core/domain/images"
+	"github.com/taldoflemis/sora-henkan/internal/core/ports"
+	imageProcessor "github.com/taldoflemis/sora-henkan/internal/infra/adapter/image_processor"
+)
+
+func TestOpenAPISchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema map[string]any
+		want   map[string]any
+	}{
+		{
+			name:   "number gets a format",
+			schema: map[string]any{"type": "number", "minimum": 0.0},
+			want:   map[string]any{"type": "number", "format": "double", "minimum": 0.0},
+		},
+		{
+			name:   "exclusive bounds become booleans",
+			schema: map[string]any{"type": "integer", "exclusiveMinimum": -360.0, "exclusiveMaximum": 360.0},
+			want: map[string]any{
+				"type": "integer", "minimum": -360.0, "exclusiveMinimum": true, "maximum": 360.0, "exclusiveMaximum": true,
+			},
+		},
+		{
+			name: "alternatives get the property type",
+			schema: map[string]any{
+				"type":  "string",
+				"anyOf": []any{map[string]any{"pattern": "^#[0-9a-f]{6}$"}, map[string]any{"const": "auto"}},
+			},
+			want: map[string]any{
+				"type": "string",
+				"anyOf": []any{
+					map[string]any{"type": "string", "pattern": "^#[0-9a-f]{6}$"},
+					map[string]any{"type": "string", "enum": []any{"auto"}},
+				},
+			},
+		},
+		{
+			name: "nested items and properties",
+			schema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"kernel": map[string]any{
+						"type":  "array",
+						"items": map[string]any{"type": "array", "items": map[string]any{"type": "number"}},
+					},
+				},
+			},
+			want: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"kernel": map[string]any{
+						"type": "array",
+						"items": map[string]any{
+							"type":  "array",
+							"items": map[string]any{"type": "number", "format": "double"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := openAPISchema(tt.schema); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("openAPISchema() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplaceGenerated(t *testing.T) {
+	spec := "components:\n  schemas:\n" + generatedBegin + "\n    Old:\n      type: object\n" + generatedEnd + "\n\n    Other:\n"
+
+	got, err := replaceGenerated(spec, "    New:\n      type: object\n")
+	if err != nil {
+		t.Fatalf("replaceGenerated() error = %v", err)
+	}
+
+	want := "components:\n  schemas:\n" + generatedBegin + "\n    New:\n      type: object\n\n" + generatedEnd + "\n\n    Other:\n"
+	if got != want {
+		t.Errorf("replaceGenerated() = %q, want %q", got, want)
+	}
+
+	if _, err := replaceGenerated(strings.ReplaceAll(spec, generatedEnd, ""), "    New:\n"); err == nil {
+		t.Error("replaceGenerated() without the end marker succeeded")
+	}
+}
+
+// namedTransformer stands in for a transformer where only the name matters
+type namedTransformer struct {
+	ports.ImageTransformer
+	name string
+}
+
+func (t namedTransformer) Name() string { return t.name }
+
+func TestRenderArgs(t *testing.T) {
+	registrations := []ports.TransformerRegistration{
+		{Transformer: namedTransformer{name: "grayscale"}},
+		{
+			Transformer:     namedTransformer{name: "load"},
+			Aliases:         []string{"page", "density"},
+			RenderArgs:      []string{"page", "dpi"},
+			AliasRenderArgs: map[string][]string{"density": {"dpi"}},
+		},
+		{Transformer: namedTransformer{name: "resize"}, RenderArgs: []string{images.RenderArgSize, "mode"}},
+	}
+
+	got, err := renderArgs(registrations)
+	if err != nil {
+		t.Fatalf("renderArgs() error = %v", err)
+	}
+
+	for _, line := range []string{
+		`"density": {"dpi"},`,
+		`"load":    {"page", "dpi"},`,
+		`"page":    {"page", "dpi"},`,
+		`"resize":  {RenderArgSize, "mode"},`,
+	} {
+		if !strings.Contains(string(got), line) {
+			t.Errorf("renderArgs() is missing %q:\n%s", line, got)
+		}
+	}
+
+	if strings.Contains(string(got), "grayscale") {
+		t.Errorf("renderArgs() lists a transformer without arguments:\n%s", got)
+	}
+}
+
+// TestRenderArgsUpToDate fails when a transformer changed its render arguments without the table being regenerated
+func TestRenderArgsUpToDate(t *testing.T) {
+	registry, err := imageProcessor.NewVipsTransformerRegistry(imageProcessor.TransformerDependencies{})
+	if err != nil {
+		t.Fatalf("NewVipsTransformerRegistry() error = %v", err)
+	}
+
+	want, err := renderArgs(registry.Registrations())
+	if err != nil {
+		t.Fatalf("renderArgs() error = %v", err)
+	}
+
+	got, err := os.ReadFile("../../internal/core/domain/images/render_args.go")
+	if err != nil {
+		t.Fatalf("failed to read render args: %v", err)
+	}
+
+	if string(got) != string(want) {
+		t.Error("internal/core/domain/images/render_args.go is out of date, run task openapi:generate")
+	}
+}
@@ -0,0 +1,2 @@
+-- Remove transformed_mime_type column from images table
+ALTER TABLE images DROP COLUMN IF EXISTS transformed_mime_type;
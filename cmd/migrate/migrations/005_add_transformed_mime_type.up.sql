@@ -0,0 +1,2 @@
+-- Add transformed_mime_type column to images table
+ALTER TABLE images ADD COLUMN IF NOT EXISTS transformed_mime_type VARCHAR(100) NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+-- Remove page_count column from images table
+ALTER TABLE images DROP COLUMN IF EXISTS page_count;
@@ -0,0 +1,2 @@
+-- Add page_count column to images table
+ALTER TABLE images ADD COLUMN IF NOT EXISTS page_count INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+-- Remove original_properties and transformed_properties columns from images table
+ALTER TABLE images DROP COLUMN IF EXISTS transformed_properties;
+ALTER TABLE images DROP COLUMN IF EXISTS original_properties;
@@ -0,0 +1,3 @@
+-- Add original_properties and transformed_properties columns to images table
+ALTER TABLE images ADD COLUMN IF NOT EXISTS original_properties JSONB;
+ALTER TABLE images ADD COLUMN IF NOT EXISTS transformed_properties JSONB;
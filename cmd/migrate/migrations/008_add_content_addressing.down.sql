@@ -0,0 +1,6 @@
+-- Drop raw_objects table
+DROP TABLE IF EXISTS raw_objects;
+
+-- Remove sha256 column from images table
+DROP INDEX IF EXISTS idx_images_sha256;
+ALTER TABLE images DROP COLUMN IF EXISTS sha256;
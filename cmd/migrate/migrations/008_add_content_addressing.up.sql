@@ -0,0 +1,11 @@
+-- Add sha256 column to images table
+ALTER TABLE images ADD COLUMN IF NOT EXISTS sha256 VARCHAR(64) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_images_sha256 ON images(sha256);
+
+-- Create raw_objects table, counting the images that share each stored original
+CREATE TABLE IF NOT EXISTS raw_objects (
+    sha256 VARCHAR(64) PRIMARY KEY,
+    object_key TEXT NOT NULL,
+    ref_count INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
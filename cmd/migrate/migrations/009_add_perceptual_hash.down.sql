@@ -0,0 +1,12 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_images_phash_band3;
+DROP INDEX IF EXISTS idx_images_phash_band2;
+DROP INDEX IF EXISTS idx_images_phash_band1;
+DROP INDEX IF EXISTS idx_images_phash_band0;
+
+-- Remove perceptual_hash columns from images table
+ALTER TABLE images DROP COLUMN IF EXISTS phash_band3;
+ALTER TABLE images DROP COLUMN IF EXISTS phash_band2;
+ALTER TABLE images DROP COLUMN IF EXISTS phash_band1;
+ALTER TABLE images DROP COLUMN IF EXISTS phash_band0;
+ALTER TABLE images DROP COLUMN IF EXISTS perceptual_hash;
@@ -0,0 +1,13 @@
+-- Add perceptual_hash column to images table
+ALTER TABLE images ADD COLUMN IF NOT EXISTS perceptual_hash BIGINT;
+
+-- Split the hash into four 16 bit bands, so near-duplicates are found through exact lookups on their indexes
+ALTER TABLE images ADD COLUMN IF NOT EXISTS phash_band0 INTEGER GENERATED ALWAYS AS ((perceptual_hash >> 48) & 65535) STORED;
+ALTER TABLE images ADD COLUMN IF NOT EXISTS phash_band1 INTEGER GENERATED ALWAYS AS ((perceptual_hash >> 32) & 65535) STORED;
+ALTER TABLE images ADD COLUMN IF NOT EXISTS phash_band2 INTEGER GENERATED ALWAYS AS ((perceptual_hash >> 16) & 65535) STORED;
+ALTER TABLE images ADD COLUMN IF NOT EXISTS phash_band3 INTEGER GENERATED ALWAYS AS (perceptual_hash & 65535) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_images_phash_band0 ON images(phash_band0);
+CREATE INDEX IF NOT EXISTS idx_images_phash_band1 ON images(phash_band1);
+CREATE INDEX IF NOT EXISTS idx_images_phash_band2 ON images(phash_band2);
+CREATE INDEX IF NOT EXISTS idx_images_phash_band3 ON images(phash_band3);
@@ -0,0 +1,4 @@
+-- Remove placeholder columns from images table
+ALTER TABLE images DROP COLUMN IF EXISTS palette;
+ALTER TABLE images DROP COLUMN IF EXISTS dominant_color;
+ALTER TABLE images DROP COLUMN IF EXISTS blurhash;
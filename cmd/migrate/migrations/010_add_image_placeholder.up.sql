@@ -0,0 +1,4 @@
+-- Add placeholder columns to images table, filled in once the image is processed
+ALTER TABLE images ADD COLUMN IF NOT EXISTS blurhash VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE images ADD COLUMN IF NOT EXISTS dominant_color VARCHAR(7) NOT NULL DEFAULT '';
+ALTER TABLE images ADD COLUMN IF NOT EXISTS palette TEXT[];
@@ -0,0 +1,2 @@
+-- Drop awaiting upload index
+DROP INDEX IF EXISTS idx_images_awaiting_upload;
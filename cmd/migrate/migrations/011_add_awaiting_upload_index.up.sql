@@ -0,0 +1,2 @@
+-- Index the reservations still awaiting an upload, which the worker sweeps for expired ones
+CREATE INDEX IF NOT EXISTS idx_images_awaiting_upload ON images(created_at) WHERE status = 'awaiting_upload';
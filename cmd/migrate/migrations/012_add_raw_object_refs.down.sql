@@ -0,0 +1,10 @@
+-- Count the references again
+ALTER TABLE raw_objects ADD COLUMN IF NOT EXISTS ref_count INTEGER NOT NULL DEFAULT 0;
+
+UPDATE raw_objects
+SET ref_count = (SELECT COUNT(*) FROM raw_object_refs WHERE raw_object_refs.sha256 = raw_objects.sha256);
+
+ALTER TABLE raw_objects ALTER COLUMN ref_count DROP DEFAULT;
+
+-- Drop raw_object_refs table
+DROP TABLE IF EXISTS raw_object_refs;
@@ -0,0 +1,16 @@
+-- Reference stored originals per image, so referencing the same original twice counts once
+CREATE TABLE IF NOT EXISTS raw_object_refs (
+    sha256 VARCHAR(64) NOT NULL REFERENCES raw_objects(sha256) ON DELETE CASCADE,
+    image_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (sha256, image_id)
+);
+
+INSERT INTO raw_object_refs (sha256, image_id)
+SELECT images.sha256, images.id
+FROM images
+JOIN raw_objects ON raw_objects.sha256 = images.sha256
+ON CONFLICT DO NOTHING;
+
+-- The references replace the count
+ALTER TABLE raw_objects DROP COLUMN IF EXISTS ref_count;
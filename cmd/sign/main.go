@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/taldoflemis/sora-henkan/internal/core/domain/images"
+	"github.com/taldoflemis/sora-henkan/settings"
+)
+
+type SignSettings struct {
+	ImageProcessor settings.ImageProcessorSettings `mapstructure:"image-processor" validate:"required"`
+}
+
+func main() {
+	var (
+		id      string
+		ops     string
+		ttl     time.Duration
+		baseURL string
+	)
+
+	flag.StringVar(&id, "id", "", "Image ID to render")
+	flag.StringVar(&ops, "ops", "", "Render ops, e.g. resize:300x200,grayscale")
+	flag.DurationVar(&ttl, "ttl", 24*time.Hour, "How long the signed URL stays valid")
+	flag.StringVar(&baseURL, "base-url", "http://localhost:42069", "Base URL of the API")
+	flag.Parse()
+
+	if id == "" || ops == "" {
+		flag.Usage()
+		log.Fatal("both -id and -ops are required")
+	}
+
+	// Load settings
+	cfg, err := settings.LoadConfig[SignSettings]("SIGN", settings.BaseSettings)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	transformations, err := images.ParseRenderOps(ops)
+	if err != nil {
+		log.Fatalf("Failed to parse ops: %v", err)
+	}
+
+	expiresAt := time.Now().Add(ttl)
+
+	signature, err := images.SignRender([]byte(cfg.ImageProcessor.RenderSigningKey), id, transformations, expiresAt)
+	if err != nil {
+		log.Fatalf("Failed to sign render: %v", err)
+	}
+
+	query := url.Values{}
+	query.Set("ops", ops)
+	query.Set("expires", strconv.FormatInt(expiresAt.Unix(), 10))
+	query.Set("signature", signature)
+
+	fmt.Printf("%s/v1/images/%s/render?%s\n", strings.TrimSuffix(baseURL, "/"), id, query.Encode())
+}
@@ -29,6 +29,9 @@ import (
 	wotel "github.com/voi-oss/watermill-opentelemetry/pkg/opentelemetry"
 )
 
+// uploadExpiryInterval is how often the worker looks for presigned uploads that were never completed
+const uploadExpiryInterval = time.Minute
+
 type WorkerSettings struct {
 	App            settings.AppSettings            `mapstructure:"app" validate:"required"`
 	Database       settings.DatabaseSettings       `mapstructure:"database" validate:"required"`
@@ -185,29 +188,44 @@ func main() {
 		return
 	}
 
+	eventPublisher, err := settings.Watermill.Broker.NewEventPublisher()
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to create event publisher", slog.Any("err", err))
+		return
+	}
+
 	// Create adapters
-	transformerFactory := imageProcessor.NewTransformerFactory(
-		imageProcessor.NewVipsResizeTransformer(),
-		imageProcessor.NewVipsGrayscaleTransformer(),
-		imageProcessor.NewVipsTrimTransformer(),
-		imageProcessor.NewVipsBlurTransformer(),
-		imageProcessor.NewVipsRotateTransformer(),
-	)
-	pipelineProcessor := imageProcessor.NewPipeline(transformerFactory)
 	objectStorerAdapter := objectStorer.NewMinioObjectStorer(minioClient)
 	imageRepository := postgres.NewPostgresImageRepository(pgxpool)
+	transformerRegistry, err := imageProcessor.NewVipsTransformerRegistry(imageProcessor.TransformerDependencies{
+		ObjectStorer:    objectStorerAdapter,
+		ImageRepository: imageRepository,
+		BucketName:      settings.ImageProcessor.BucketName,
+		FontsDir:        settings.ImageProcessor.FontsDir,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to register image transformers", slog.Any("err", err))
+		return
+	}
+	pipelineProcessor := imageProcessor.NewPipeline(transformerRegistry)
+	imageInspector := imageProcessor.NewVipsImageInspector()
 	metadataRepository := dynamodbAdapter.NewDynamoDBImageMetadataRepository(dynamoClient, settings.DynamoDB.Table)
 
 	// Create usecases
 	imageUseCase := application.NewImageUseCase(
 		publisher,
-		subscriber,
+		eventPublisher,
+		nil, // the worker only publishes image status events, it never streams them
 		imageRepository,
 		metadataRepository,
 		pipelineProcessor,
+		imageInspector,
 		objectStorerAdapter,
 		settings.ImageProcessor.BucketName,
 		settings.Watermill.ImageTopic,
+		settings.Watermill.ImageStatusTopic,
+		time.Duration(settings.ImageProcessor.PresignedURLExpiryInSec)*time.Second,
+		settings.ImageProcessor.RenderSigningKey,
 	)
 
 	slog.InfoContext(ctx, "Setting up Watermill router")
@@ -237,6 +255,9 @@ func main() {
 	}.Middleware)
 	router.AddPlugin(plugin.SignalsHandler)
 
+	// Every worker sweeps, the conditional status update keeps them from expiring an upload twice
+	go expireUploads(ctx, imageUseCase)
+
 	slog.InfoContext(ctx, "Starting Watermill router")
 
 	errChan := make(chan error, 1)
@@ -265,6 +286,23 @@ func main() {
 	retcode = 0
 }
 
+// expireUploads periodically fails the presigned uploads that were never completed, until ctx is done
+func expireUploads(ctx context.Context, imageUseCase *application.ImageUseCase) {
+	ticker := time.NewTicker(uploadExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := imageUseCase.ExpireUploads(ctx); err != nil {
+				slog.ErrorContext(ctx, "failed to expire uploads", slog.Any("err", err))
+			}
+		}
+	}
+}
+
 // handlePendingImage processes pending image messages
 func handlePendingImage(msg *message.Message, imageUseCase *application.ImageUseCase) error {
 	ctx := msg.Context()
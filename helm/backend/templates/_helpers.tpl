@@ -112,6 +112,14 @@ Create the name of the service account to use
 {{- end }}
 {{- end }}
 
+{{- define "backend.renderSigningKey" -}}
+- name: {{ .prefix }}_IMAGEPROCESSOR_RENDERSIGNINGKEY
+  valueFrom:
+    secretKeyRef:
+      name: {{ required "imageProcessor.renderSigningKey.secretName is required" .Values.imageProcessor.renderSigningKey.secretName }}
+      key: {{ required "imageProcessor.renderSigningKey.key is required" .Values.imageProcessor.renderSigningKey.key }}
+{{- end }}
+
 {{- define "backend.opentelemetry" -}}
 {{- $prefix := .prefix | upper -}}
 - name: {{ .prefix }}_OPENTELEMETRY_ENABLED
@@ -158,6 +166,8 @@ Create the name of the service account to use
 {{- $prefix := .prefix | upper -}}
 - name: {{ .prefix }}_WATERMILL_IMAGETOPIC
   value: {{ .Values.watermill.imageTopic | quote }}
+- name: {{ .prefix }}_WATERMILL_IMAGESTATUSTOPIC
+  value: {{ .Values.watermill.imageStatusTopic | quote }}
 - name: {{ .prefix }}_WATERMILL_BROKER_KIND
   value: {{ .Values.watermill.broker.kind | quote }}
 
@@ -186,6 +196,10 @@ Create the name of the service account to use
 {{- end }}
 - name: {{ .prefix }}_WATERMILL_BROKER_AWS_REGION
   value: {{ .Values.watermill.broker.aws.region }}
+- name: {{ .prefix }}_WATERMILL_BROKER_AWS_ACCOUNTID
+  value: {{ .Values.watermill.broker.aws.accountId | quote }}
+- name: {{ .prefix }}_WATERMILL_BROKER_AWS_SNSENDPOINT
+  value: {{ .Values.watermill.broker.aws.snsEndpoint | quote }}
 
 {{- else if .Values.watermill.broker.kind | eq "amqp" }}
 - name: {{ .prefix }}_WATERMILL_BROKER_AMQP_HOST
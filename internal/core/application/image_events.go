@@ -0,0 +1,123 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/taldoflemis/sora-henkan/internal/core/domain/images"
+)
+
+// imageEventListenerBuffer is how many events a slow SSE client may lag behind before events are dropped
+const imageEventListenerBuffer = 32
+
+// imageEventBroadcaster holds a single subscription to the image status topic
+// per process and fans every event out to the in-process listeners
+type imageEventBroadcaster struct {
+	subscriber message.Subscriber
+	topic      string
+
+	mu        sync.Mutex
+	started   bool
+	listeners map[chan *images.Image]struct{}
+}
+
+func newImageEventBroadcaster(subscriber message.Subscriber, topic string) *imageEventBroadcaster {
+	return &imageEventBroadcaster{
+		subscriber: subscriber,
+		topic:      topic,
+		listeners:  make(map[chan *images.Image]struct{}),
+	}
+}
+
+// listen registers a new listener, subscribing to the topic on first use.
+// The returned function unregisters the listener. The listener is closed when the subscription ends.
+func (b *imageEventBroadcaster) listen(ctx context.Context) (chan *images.Image, func(), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.started {
+		// The subscription outlives the request that triggered it
+		ch, err := b.subscriber.Subscribe(context.Background(), b.topic)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		go b.run(ch)
+		b.started = true
+	}
+
+	listener := make(chan *images.Image, imageEventListenerBuffer)
+	b.listeners[listener] = struct{}{}
+
+	slog.DebugContext(ctx, "image event listener registered", slog.Int("listeners", len(b.listeners)))
+
+	return listener, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.listeners, listener)
+	}, nil
+}
+
+func (b *imageEventBroadcaster) run(ch <-chan *message.Message) {
+	for msg := range ch {
+		event := images.ImageStatusChangedEvent{}
+
+		err := json.Unmarshal(msg.Payload, &event)
+		// Events are informational only, redelivering a malformed one won't help
+		msg.Ack()
+		if err != nil {
+			slog.ErrorContext(msg.Context(), "failed to unmarshal image status event", slog.Any("err", err))
+			continue
+		}
+
+		b.mu.Lock()
+		for listener := range b.listeners {
+			image := event.Image
+			select {
+			case listener <- &image:
+			default:
+				slog.WarnContext(msg.Context(), "image event listener is lagging behind, dropping event",
+					slog.String("image_id", image.ID.String()))
+			}
+		}
+		b.mu.Unlock()
+	}
+
+	// The subscription ended, so end the streams listening to it too. Clients reconnect,
+	// which subscribes again.
+	b.mu.Lock()
+	for listener := range b.listeners {
+		close(listener)
+	}
+	clear(b.listeners)
+	b.started = false
+	b.mu.Unlock()
+
+	slog.Warn("image status subscription closed")
+}
+
+// publishImageStatusChanged notifies realtime listeners that an image changed state.
+// Failures are only logged since the image state itself is already persisted.
+func (u *ImageUseCase) publishImageStatusChanged(ctx context.Context, image *images.Image) {
+	event := images.ImageStatusChangedEvent{
+		Image:      *image,
+		OccurredAt: time.Now(),
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to marshal image status event", slog.Any("err", err))
+		return
+	}
+
+	msg := message.NewMessageWithContext(ctx, watermill.NewUUID(), payload)
+
+	if err := u.eventPublisher.Publish(u.imageStatusTopic, msg); err != nil {
+		slog.WarnContext(ctx, "failed to publish image status event", slog.Any("err", err))
+	}
+}
@@ -1,10 +1,13 @@
 package application
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,6 +17,8 @@ import (
 	"mime"
 	"net/http"
 	"net/url"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -50,38 +55,63 @@ var allowedMIMETypes = map[string]bool{
 var (
 	rawImagePath         = "raw-images"
 	transformedImagePath = "transformed-images"
+	derivedImagePath     = "derived-images"
+	pagedImagePath       = "image-pages"
+)
+
+const (
+	// uploadExpiryGracePeriod lets an upload started just before its URL expired finish before the reservation expires
+	uploadExpiryGracePeriod = 15 * time.Minute
+
+	// expiredUploadsBatchSize bounds how many reservations a single ExpireUploads call expires
+	expiredUploadsBatchSize = 100
 )
 
 type ImageUseCase struct {
 	imageRepository    ports.ImageRepository
 	metadataRepository ports.ImageMetadataRepository
 	pipelineProcessor  ports.ImagePipelineProcessor
+	imageInspector     ports.ImageInspector
 	objectStorer       ports.ObjectStorer
 	imagesBucket       string
 	publisher          message.Publisher
-	subscriber         message.Subscriber
 	imageTopic         string
+	eventPublisher     message.Publisher
+	imageStatusTopic   string
+	imageEvents        *imageEventBroadcaster
+	presignedURLExpiry time.Duration
+	renderSigningKey   []byte
 }
 
 func NewImageUseCase(
 	publisher message.Publisher,
-	subscriber message.Subscriber,
+	eventPublisher message.Publisher,
+	eventSubscriber message.Subscriber,
 	imageRepository ports.ImageRepository,
 	metadataRepository ports.ImageMetadataRepository,
 	pipelineProcessor ports.ImagePipelineProcessor,
+	imageInspector ports.ImageInspector,
 	objectStorer ports.ObjectStorer,
 	imagesBucket string,
 	imageTopic string,
+	imageStatusTopic string,
+	presignedURLExpiry time.Duration,
+	renderSigningKey string,
 ) *ImageUseCase {
 	return &ImageUseCase{
 		publisher:          publisher,
-		subscriber:         subscriber,
+		eventPublisher:     eventPublisher,
 		imageRepository:    imageRepository,
 		metadataRepository: metadataRepository,
 		pipelineProcessor:  pipelineProcessor,
+		imageInspector:     imageInspector,
 		imagesBucket:       imagesBucket,
 		objectStorer:       objectStorer,
 		imageTopic:         imageTopic,
+		imageStatusTopic:   imageStatusTopic,
+		imageEvents:        newImageEventBroadcaster(eventSubscriber, imageStatusTopic),
+		presignedURLExpiry: presignedURLExpiry,
+		renderSigningKey:   []byte(renderSigningKey),
 	}
 }
 
@@ -110,7 +140,7 @@ func (u *ImageUseCase) CreateImageRequest(ctx context.Context, req *images.Creat
 		OriginalImageURL: req.ImageURL,
 		Transformations:  images.TransformationList(req.Transformations),
 		CreatedAt:        time.Now(),
-		Status:           "pending",
+		Status:           images.StatusPending,
 		UpdatedAt:        time.Now(),
 	}
 
@@ -127,46 +157,585 @@ func (u *ImageUseCase) CreateImageRequest(ctx context.Context, req *images.Creat
 		// Don't fail the request if metadata save fails - it's supplementary
 	}
 
-	processReq := &images.ProcessImageRequest{
-		ID:               imageEntity.ID.String(),
-		OriginalImageURL: imageEntity.OriginalImageURL,
-		StorageKey:       imageEntity.ObjectStorageImageKey,
-		Transformations:  req.Transformations,
+	err = u.publishProcessImageRequest(ctx, &imageEntity, req.Transformations)
+	if err != nil {
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
 	}
 
-	payload, err := json.Marshal(processReq)
+	u.publishImageStatusChanged(ctx, &imageEntity)
+
+	return &images.CreateImageResponse{
+		ID: imageEntity.ID.String(),
+	}, nil
+}
+
+func (u *ImageUseCase) UploadImage(ctx context.Context, req *images.UploadImageRequest, file io.Reader) (*images.CreateImageResponse, error) {
+	ctx, span := tracer.Start(ctx, "ImageUseCase.UploadImage", trace.WithAttributes(
+		attribute.String("image.filename", req.Filename),
+	))
+	defer span.End()
+
+	// Validate request
+	if err := ValidateStruct(req); err != nil {
+		slog.ErrorContext(ctx, "validation failed", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	// Validate transformations before accepting any bytes
+	if err := u.pipelineProcessor.ValidateTransformations(ctx, req.Transformations); err != nil {
+		slog.ErrorContext(ctx, "transformation validation failed", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	imageID := uuid.New()
+
+	storedImage, err := u.storeRawImage(ctx, imageID.String(), filepath.Ext(req.Filename), file)
 	if err != nil {
-		slog.ErrorContext(ctx, "failed to marshal process image request", slog.Any("err", err))
+		slog.ErrorContext(ctx, "failed to store uploaded image", slog.Any("err", err))
 		telemetry.RegisterSpanError(span, err)
 		return nil, err
 	}
 
-	message := message.NewMessageWithContext(ctx, watermill.NewUUID(), payload)
+	storedKey, err := u.acquireOriginal(ctx, storedImage.SHA256, imageID.String(), storedImage.ObjectStorageImageKey)
+	if err != nil {
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
 
-	err = u.publisher.Publish(u.imageTopic, message)
+	imageEntity := images.Image{
+		ID:                    imageID,
+		ObjectStorageImageKey: storedKey,
+		MimeType:              storedImage.MimeType,
+		Checksum:              storedImage.Checksum,
+		SHA256:                storedImage.SHA256,
+		Transformations:       images.TransformationList(req.Transformations),
+		CreatedAt:             time.Now(),
+		Status:                images.StatusPending,
+		UpdatedAt:             time.Now(),
+	}
+
+	err = u.imageRepository.CreateNewImage(ctx, &imageEntity)
 	if err != nil {
-		slog.ErrorContext(ctx, "failed to publish image request", slog.Any("err", err))
+		slog.ErrorContext(ctx, "failed to create image", slog.Any("err", err))
 		telemetry.RegisterSpanError(span, err)
+
+		// Don't leave an orphan object behind, unless another image shares it
+		if releaseErr := u.releaseOriginal(ctx, imageEntity.SHA256, imageID.String()); releaseErr != nil {
+			slog.WarnContext(ctx, "failed to delete orphan uploaded image", slog.Any("err", releaseErr))
+		}
+		u.dropDuplicateOriginal(ctx, storedImage.ObjectStorageImageKey, storedKey)
+
 		return nil, err
 	}
 
+	u.dropDuplicateOriginal(ctx, storedImage.ObjectStorageImageKey, storedKey)
+
+	// Save metadata to DynamoDB for fast querying
+	if err := u.metadataRepository.SaveMetadata(ctx, &imageEntity); err != nil {
+		slog.WarnContext(ctx, "failed to save image metadata to DynamoDB", slog.Any("err", err))
+	}
+
+	// The storage key is already set, so the worker skips the fetch step
+	err = u.publishProcessImageRequest(ctx, &imageEntity, req.Transformations)
+	if err != nil {
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	u.publishImageStatusChanged(ctx, &imageEntity)
+
+	slog.InfoContext(ctx, "image uploaded successfully", slog.String("image_id", imageEntity.ID.String()))
+
 	return &images.CreateImageResponse{
 		ID: imageEntity.ID.String(),
 	}, nil
 }
 
+// CreateUpload reserves an image ID and returns a presigned URL the client uses to
+// PUT the original image straight into the bucket, so large files never go through the API.
+func (u *ImageUseCase) CreateUpload(ctx context.Context, req *images.CreateUploadRequest) (*images.CreateUploadResponse, error) {
+	ctx, span := tracer.Start(ctx, "ImageUseCase.CreateUpload", trace.WithAttributes(
+		attribute.String("image.filename", req.Filename),
+	))
+	defer span.End()
+
+	// Validate request
+	if err := ValidateStruct(req); err != nil {
+		slog.ErrorContext(ctx, "validation failed", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	// Validate transformations before handing out an upload URL
+	if err := u.pipelineProcessor.ValidateTransformations(ctx, req.Transformations); err != nil {
+		slog.ErrorContext(ctx, "transformation validation failed", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	imageEntity := images.Image{
+		ID:              uuid.New(),
+		Transformations: images.TransformationList(req.Transformations),
+		CreatedAt:       time.Now(),
+		Status:          images.StatusAwaitingUpload,
+		UpdatedAt:       time.Now(),
+	}
+	imageEntity.ObjectStorageImageKey = rawImagePath + "/" + imageEntity.ID.String() + filepath.Ext(req.Filename)
+
+	expiresAt := time.Now().Add(u.presignedURLExpiry)
+
+	uploadURL, err := u.objectStorer.PresignedUpload(ctx, imageEntity.ObjectStorageImageKey, u.imagesBucket, u.presignedURLExpiry)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to generate presigned upload URL", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	err = u.imageRepository.CreateNewImage(ctx, &imageEntity)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to create image", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	// Save metadata to DynamoDB for fast querying
+	if err := u.metadataRepository.SaveMetadata(ctx, &imageEntity); err != nil {
+		slog.WarnContext(ctx, "failed to save image metadata to DynamoDB", slog.Any("err", err))
+	}
+
+	u.publishImageStatusChanged(ctx, &imageEntity)
+
+	slog.InfoContext(ctx, "image upload reserved", slog.String("image_id", imageEntity.ID.String()))
+
+	return &images.CreateUploadResponse{
+		ID:        imageEntity.ID.String(),
+		UploadURL: uploadURL,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// CompleteUpload is called by the client once its presigned upload finished. It checks the
+// object really is in the bucket, sniffs its MIME type, records its checksum and enqueues processing.
+// Concurrent completions of the same upload are safe, only the first one enqueues the image.
+func (u *ImageUseCase) CompleteUpload(ctx context.Context, req *images.CompleteUploadRequest) (*images.Image, error) {
+	ctx, span := tracer.Start(ctx, "ImageUseCase.CompleteUpload", trace.WithAttributes(attribute.String("image.id", req.ID)))
+	defer span.End()
+
+	// Validate request
+	if err := ValidateStruct(req); err != nil {
+		slog.ErrorContext(ctx, "validation failed", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	imageEntity, err := u.imageRepository.FindImageByID(ctx, req.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to find image", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	if imageEntity.Status != images.StatusAwaitingUpload {
+		err = images.ErrImageNotAwaitingUpload
+		slog.ErrorContext(ctx, "image is not awaiting an upload", slog.String("status", imageEntity.Status))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	objectInfo, err := u.objectStorer.Stat(ctx, imageEntity.ObjectStorageImageKey, u.imagesBucket)
+	if err != nil {
+		if errors.Is(err, ports.ErrObjectNotFound) {
+			err = images.ErrUploadNotFound
+		}
+		slog.ErrorContext(ctx, "failed to find uploaded object", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	// Only the head of the object is needed to sniff its type, the rest never reaches the API
+	head, err := u.objectStorer.GetRange(ctx, imageEntity.ObjectStorageImageKey, u.imagesBucket, 0, 512)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get uploaded object", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+	defer head.Close()
+
+	mimeType, _, err := detectMIMEType(head)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to read uploaded object", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	if !allowedMIMETypes[mimeType] {
+		err = fmt.Errorf("%w: %s", images.ErrDisallowedMIMEType, mimeType)
+		slog.ErrorContext(ctx, "disallowed MIME type", slog.String("mime_type", mimeType))
+		telemetry.RegisterSpanError(span, err)
+
+		// The object is useless, so reject the image for good
+		if deleteErr := u.objectStorer.Delete(ctx, imageEntity.ObjectStorageImageKey, u.imagesBucket); deleteErr != nil {
+			slog.WarnContext(ctx, "failed to delete rejected uploaded object", slog.Any("err", deleteErr))
+		}
+
+		imageEntity.Status = images.StatusFailed
+		imageEntity.ErrorMessage = err.Error()
+		imageEntity.UpdatedAt = time.Now()
+
+		if updateErr := u.imageRepository.UpdateImageFromStatus(ctx, imageEntity, images.StatusAwaitingUpload); updateErr != nil {
+			slog.ErrorContext(ctx, "failed to update image status to failed", slog.Any("err", updateErr))
+		} else {
+			if metaErr := u.metadataRepository.UpdateMetadata(ctx, imageEntity); metaErr != nil {
+				slog.WarnContext(ctx, "failed to update image metadata in DynamoDB", slog.Any("err", metaErr))
+			}
+
+			u.publishImageStatusChanged(ctx, imageEntity)
+		}
+
+		return nil, err
+	}
+
+	// Clients may send x-amz-checksum-crc32c with their upload, which spares reading the object back
+	checksum := objectInfo.ChecksumCRC32C
+	if checksum == "" {
+		checksum, err = u.checksumObject(ctx, imageEntity.ObjectStorageImageKey)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to compute checksum of uploaded object", slog.Any("err", err))
+			telemetry.RegisterSpanError(span, err)
+			return nil, err
+		}
+	}
+
+	imageEntity.MimeType = mimeType
+	imageEntity.Checksum = checksum
+	imageEntity.Status = images.StatusPending
+	imageEntity.UpdatedAt = time.Now()
+
+	// Only one of several concurrent completions gets past this point
+	err = u.imageRepository.UpdateImageFromStatus(ctx, imageEntity, images.StatusAwaitingUpload)
+	if err != nil {
+		if errors.Is(err, ports.ErrImageStatusChanged) {
+			err = images.ErrImageNotAwaitingUpload
+		}
+		slog.ErrorContext(ctx, "failed to update image", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	// Update metadata in DynamoDB
+	if err := u.metadataRepository.UpdateMetadata(ctx, imageEntity); err != nil {
+		slog.WarnContext(ctx, "failed to update image metadata in DynamoDB", slog.Any("err", err))
+	}
+
+	err = u.publishProcessImageRequest(ctx, imageEntity, imageEntity.Transformations)
+	if err != nil {
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	u.publishImageStatusChanged(ctx, imageEntity)
+
+	slog.InfoContext(ctx, "image upload completed", slog.String("image_id", imageEntity.ID.String()))
+
+	return imageEntity, nil
+}
+
+// checksumObject streams a stored object through CRC32C without keeping it in memory
+func (u *ImageUseCase) checksumObject(ctx context.Context, key string) (string, error) {
+	object, err := u.objectStorer.Get(ctx, key, u.imagesBucket)
+	if err != nil {
+		return "", err
+	}
+	defer object.Close()
+
+	hasher := crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	if _, err := io.Copy(hasher, object); err != nil {
+		return "", fmt.Errorf("failed to read object: %w", err)
+	}
+
+	return encodeCRC32C(hasher.Sum32()), nil
+}
+
+// ExpireUploads fails the images whose presigned upload was never completed and deletes whatever
+// was uploaded for them. A reservation expires once its upload URL has expired, plus a grace period
+// for an upload that was started just before.
+func (u *ImageUseCase) ExpireUploads(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "ImageUseCase.ExpireUploads")
+	defer span.End()
+
+	reservedBefore := time.Now().Add(-u.presignedURLExpiry - uploadExpiryGracePeriod)
+
+	expired, err := u.imageRepository.FindExpiredUploads(ctx, reservedBefore, expiredUploadsBatchSize)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to find expired uploads", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return err
+	}
+
+	for i := range expired {
+		imageEntity := &expired[i]
+
+		imageEntity.Status = images.StatusFailed
+		imageEntity.ErrorMessage = images.ErrUploadExpired.Error()
+		imageEntity.UpdatedAt = time.Now()
+
+		// A completion that got in first wins, the image is no longer ours to expire
+		err := u.imageRepository.UpdateImageFromStatus(ctx, imageEntity, images.StatusAwaitingUpload)
+		if errors.Is(err, ports.ErrImageStatusChanged) {
+			continue
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to expire upload", slog.Any("err", err), slog.String("image_id", imageEntity.ID.String()))
+			telemetry.RegisterSpanError(span, err)
+			return err
+		}
+
+		if err := u.objectStorer.Delete(ctx, imageEntity.ObjectStorageImageKey, u.imagesBucket); err != nil && !errors.Is(err, ports.ErrObjectNotFound) {
+			slog.WarnContext(ctx, "failed to delete object of expired upload", slog.Any("err", err))
+		}
+
+		if err := u.metadataRepository.UpdateMetadata(ctx, imageEntity); err != nil {
+			slog.WarnContext(ctx, "failed to update image metadata in DynamoDB", slog.Any("err", err))
+		}
+
+		u.publishImageStatusChanged(ctx, imageEntity)
+
+		slog.InfoContext(ctx, "upload expired", slog.String("image_id", imageEntity.ID.String()))
+	}
+
+	span.SetAttributes(attribute.Int("expired", len(expired)))
+	return nil
+}
+
 func (u *ImageUseCase) GetImage(ctx context.Context, id string) (*images.Image, error) {
 	ctx, span := tracer.Start(ctx, "ImageUseCase.GetImage", trace.WithAttributes(attribute.String("image.id", id)))
 	defer span.End()
 
-	storedImage, err := u.imageRepository.FindImageByID(ctx, id)
+	storedImage, err := u.imageRepository.FindImageByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to find image", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	return storedImage, nil
+}
+
+// GetImageContent opens the requested copy of an image for streaming.
+// A page above 0 picks one page of a transformed image loaded with a page range.
+// The caller must close the returned body.
+func (u *ImageUseCase) GetImageContent(ctx context.Context, id string, variant images.ImageVariant, page int) (*images.ImageContent, error) {
+	ctx, span := tracer.Start(ctx, "ImageUseCase.GetImageContent", trace.WithAttributes(
+		attribute.String("image.id", id),
+		attribute.String("image.variant", string(variant)),
+		attribute.Int("image.page", page),
+	))
+	defer span.End()
+
+	storedImage, err := u.imageRepository.FindImageByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to find image", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	key, err := imageVariantKey(storedImage, variant, page)
+	if err != nil {
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	objectInfo, err := u.objectStorer.Stat(ctx, key, u.imagesBucket)
+	if err != nil {
+		if errors.Is(err, ports.ErrObjectNotFound) {
+			err = images.ErrImageContentNotAvailable
+		}
+		slog.ErrorContext(ctx, "failed to stat image object", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	body, err := u.objectStorer.Get(ctx, key, u.imagesBucket)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get image object", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	// The stored checksum only describes the original, transformed copies use the bucket ETag
+	etag := objectInfo.ETag
+	if variant == images.ImageVariantOriginal && storedImage.Checksum != "" {
+		etag = storedImage.Checksum
+	}
+
+	// Images processed before format conversion existed kept the original's type
+	mimeType := storedImage.MimeType
+	if variant == images.ImageVariantTransformed && storedImage.TransformedMimeType != "" {
+		mimeType = storedImage.TransformedMimeType
+	}
+
+	return &images.ImageContent{
+		Body:       body,
+		MimeType:   mimeType,
+		ETag:       `"` + etag + `"`,
+		ModifiedAt: objectInfo.LastModified,
+	}, nil
+}
+
+// RenderImage runs the original image through the pipeline described by req.Ops,
+// as long as the request carries a valid signature for them. Results are cached in the bucket under a hash of the canonicalized ops, so each
+// rendition is only processed once. The caller must close the returned body.
+func (u *ImageUseCase) RenderImage(ctx context.Context, req *images.RenderImageRequest) (*images.ImageContent, error) {
+	ctx, span := tracer.Start(ctx, "ImageUseCase.RenderImage", trace.WithAttributes(
+		attribute.String("image.id", req.ID),
+		attribute.String("image.ops", req.Ops),
+	))
+	defer span.End()
+
+	// Validate request
+	if err := ValidateStruct(req); err != nil {
+		slog.ErrorContext(ctx, "validation failed", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	transformations, err := images.ParseRenderOps(req.Ops)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to parse render ops", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	// Check the signature before doing any work, so only URLs we handed out can burn CPU
+	err = images.VerifyRender(u.renderSigningKey, req.ID, transformations, time.Unix(req.Expires, 0), req.Signature)
+	if err != nil {
+		slog.WarnContext(ctx, "rejected render request", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	if err := u.pipelineProcessor.ValidateTransformations(ctx, transformations); err != nil {
+		err = fmt.Errorf("%w: %w", images.ErrInvalidRenderOps, err)
+		slog.ErrorContext(ctx, "transformation validation failed", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	storedImage, err := u.imageRepository.FindImageByID(ctx, req.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to find image", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	originalKey, err := imageVariantKey(storedImage, images.ImageVariantOriginal, 0)
+	if err != nil {
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	opsHash, err := images.HashTransformations(transformations)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to hash render ops", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	// The ops may change the output format, so the key carries no extension and the type lives on the object
+	derivedKey := derivedImagePath + "/" + storedImage.ID.String() + "/" + opsHash
+	span.SetAttributes(attribute.String("image.derived_key", derivedKey))
+
+	// The same image and ops always render the same bytes
+	etag := `"` + opsHash + `"`
+
+	objectInfo, err := u.objectStorer.Stat(ctx, derivedKey, u.imagesBucket)
+	if err == nil {
+		body, err := u.objectStorer.Get(ctx, derivedKey, u.imagesBucket)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to get cached rendition", slog.Any("err", err))
+			telemetry.RegisterSpanError(span, err)
+			return nil, err
+		}
+
+		slog.DebugContext(ctx, "serving cached rendition", slog.String("key", derivedKey))
+
+		return &images.ImageContent{
+			Body:       body,
+			MimeType:   objectInfo.ContentType,
+			ETag:       etag,
+			ModifiedAt: objectInfo.LastModified,
+		}, nil
+	}
+	if !errors.Is(err, ports.ErrObjectNotFound) {
+		slog.ErrorContext(ctx, "failed to stat cached rendition", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	original, err := u.objectStorer.Get(ctx, originalKey, u.imagesBucket)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get image", slog.Any("err", err), slog.String("bucket-name", u.imagesBucket))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+	defer original.Close()
+
+	rendered, err := u.pipelineProcessor.ProcessPipeline(ctx, original, transformations)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to render image", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	// A failed cache write only costs a re-render next time
+	err = u.objectStorer.Store(ctx, derivedKey, u.imagesBucket, rendered.MimeType, bytes.NewReader(rendered.Data))
+	if err != nil {
+		slog.WarnContext(ctx, "failed to cache rendition", slog.Any("err", err), slog.String("bucket-name", u.imagesBucket))
+	}
+
+	slog.InfoContext(ctx, "image rendered successfully", slog.String("image_id", req.ID), slog.String("key", derivedKey))
+
+	return &images.ImageContent{
+		Body:       nopSeekCloser{bytes.NewReader(rendered.Data)},
+		MimeType:   rendered.MimeType,
+		ETag:       etag,
+		ModifiedAt: time.Now(),
+	}, nil
+}
+
+// GetImageDownloadURL returns a short-lived presigned URL to the requested copy of an image
+func (u *ImageUseCase) GetImageDownloadURL(ctx context.Context, id string, variant images.ImageVariant, page int) (string, error) {
+	ctx, span := tracer.Start(ctx, "ImageUseCase.GetImageDownloadURL", trace.WithAttributes(
+		attribute.String("image.id", id),
+		attribute.String("image.variant", string(variant)),
+		attribute.Int("image.page", page),
+	))
+	defer span.End()
+
+	storedImage, err := u.imageRepository.FindImageByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to find image", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return "", err
+	}
+
+	key, err := imageVariantKey(storedImage, variant, page)
 	if err != nil {
-		slog.ErrorContext(ctx, "failed to find image", slog.Any("err", err))
 		telemetry.RegisterSpanError(span, err)
-		return nil, err
+		return "", err
 	}
 
-	return storedImage, nil
+	downloadURL, err := u.objectStorer.PresignedDownload(ctx, key, u.imagesBucket, u.presignedURLExpiry)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to generate presigned download URL", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return "", err
+	}
+
+	return downloadURL, nil
 }
 
 func (u *ImageUseCase) GetImageMetadata(ctx context.Context, id string) (*images.ImageMetadata, error) {
@@ -223,6 +792,8 @@ func (u *ImageUseCase) UpdateImage(ctx context.Context, req *images.UpdateImageR
 	}
 
 	imageEntity.Transformations = images.TransformationList(req.Transformations)
+	imageEntity.Status = images.StatusPending
+	imageEntity.ErrorMessage = ""
 	imageEntity.UpdatedAt = time.Now()
 
 	err = u.imageRepository.UpdateImage(ctx, imageEntity)
@@ -237,28 +808,13 @@ func (u *ImageUseCase) UpdateImage(ctx context.Context, req *images.UpdateImageR
 		slog.WarnContext(ctx, "failed to update image metadata in DynamoDB", slog.Any("err", err))
 	}
 
-	processReq := &images.ProcessImageRequest{
-		ID:               imageEntity.ID.String(),
-		OriginalImageURL: imageEntity.OriginalImageURL,
-		StorageKey:       imageEntity.ObjectStorageImageKey,
-		Transformations:  req.Transformations,
-	}
-
-	payload, err := json.Marshal(processReq)
+	err = u.publishProcessImageRequest(ctx, imageEntity, req.Transformations)
 	if err != nil {
-		slog.ErrorContext(ctx, "failed to marshal process image request", slog.Any("err", err))
 		telemetry.RegisterSpanError(span, err)
 		return err
 	}
 
-	message := message.NewMessageWithContext(ctx, watermill.NewUUID(), payload)
-
-	err = u.publisher.Publish(u.imageTopic, message)
-	if err != nil {
-		slog.ErrorContext(ctx, "failed to publish image request", slog.Any("err", err))
-		telemetry.RegisterSpanError(span, err)
-		return err
-	}
+	u.publishImageStatusChanged(ctx, imageEntity)
 
 	return nil
 }
@@ -267,7 +823,14 @@ func (u *ImageUseCase) DeleteImage(ctx context.Context, id string) error {
 	ctx, span := tracer.Start(ctx, "ImageUseCase.DeleteImage", trace.WithAttributes(attribute.String("image.id", id)))
 	defer span.End()
 
-	err := u.imageRepository.DeleteImage(ctx, id)
+	storedImage, err := u.imageRepository.FindImageByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to find image", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return err
+	}
+
+	err = u.imageRepository.DeleteImage(ctx, id)
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to delete image", slog.Any("err", err))
 		telemetry.RegisterSpanError(span, err)
@@ -279,18 +842,43 @@ func (u *ImageUseCase) DeleteImage(ctx context.Context, id string) error {
 		slog.WarnContext(ctx, "failed to delete image metadata from DynamoDB", slog.Any("err", err))
 	}
 
-	err = u.objectStorer.Delete(ctx, rawImagePath+"/"+id, u.imagesBucket)
+	// Originals are shared by images with identical bytes, so only the last reference deletes the object
+	if storedImage.SHA256 != "" {
+		err = u.releaseOriginal(ctx, storedImage.SHA256, id)
+	} else if storedImage.ObjectStorageImageKey != "" {
+		err = u.objectStorer.Delete(ctx, storedImage.ObjectStorageImageKey, u.imagesBucket)
+	}
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to delete image", slog.Any("err", err))
 		telemetry.RegisterSpanError(span, err)
 		return err
 	}
 
-	err = u.objectStorer.Delete(ctx, transformedImagePath+"/"+id, u.imagesBucket)
-	if err != nil {
-		slog.ErrorContext(ctx, "failed to delete image", slog.Any("err", err))
-		telemetry.RegisterSpanError(span, err)
-		return err
+	// The keys carry the extension of the image, so they are deleted as stored rather than rebuilt from the ID
+	if storedImage.TransformedImageKey != "" {
+		err = u.objectStorer.Delete(ctx, storedImage.TransformedImageKey, u.imagesBucket)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to delete image", slog.Any("err", err))
+			telemetry.RegisterSpanError(span, err)
+			return err
+		}
+	}
+
+	// Cached renditions are cheap to rebuild, so leftovers are not worth failing over
+	if err := u.objectStorer.DeletePrefix(ctx, derivedImagePath+"/"+id+"/", u.imagesBucket); err != nil {
+		slog.WarnContext(ctx, "failed to delete cached renditions", slog.Any("err", err))
+	}
+
+	if err := u.objectStorer.DeletePrefix(ctx, pagedImagePath+"/"+id+"/", u.imagesBucket); err != nil {
+		slog.WarnContext(ctx, "failed to delete image pages", slog.Any("err", err))
+	}
+
+	if imageID, err := uuid.Parse(id); err == nil {
+		u.publishImageStatusChanged(ctx, &images.Image{
+			ID:        imageID,
+			Status:    images.StatusDeleted,
+			UpdatedAt: time.Now(),
+		})
 	}
 
 	slog.InfoContext(ctx, "image deleted successfully", slog.String("image_id", id))
@@ -327,7 +915,22 @@ func (u *ImageUseCase) ProcessImage(ctx context.Context, req *images.ProcessImag
 		return err
 	}
 
-	if req.StorageKey == "" {
+	if imageEntity.Status != images.StatusProcessing {
+		imageEntity.Status = images.StatusProcessing
+		imageEntity.UpdatedAt = time.Now()
+
+		err = u.imageRepository.UpdateImage(ctx, imageEntity)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to update image status to processing", slog.Any("err", err))
+			telemetry.RegisterSpanError(span, err)
+			return err
+		}
+
+		u.publishImageStatusChanged(ctx, imageEntity)
+	}
+
+	// A redelivered request may still lack the key of an image an earlier attempt already fetched
+	if req.StorageKey == "" && imageEntity.ObjectStorageImageKey == "" {
 		slog.WarnContext(ctx, "image is not stored yet, fetching image")
 		imageData, err := u.fetchAndStoreImage(ctx, req)
 		if err != nil {
@@ -338,7 +941,7 @@ func (u *ImageUseCase) ProcessImage(ctx context.Context, req *images.ProcessImag
 			var nonRetryableErr *images.NonRetryableError
 			if errors.As(err, &nonRetryableErr) {
 				// Update image status to failed
-				imageEntity.Status = "failed"
+				imageEntity.Status = images.StatusFailed
 				imageEntity.ErrorMessage = nonRetryableErr.Error()
 				imageEntity.UpdatedAt = time.Now()
 
@@ -350,6 +953,8 @@ func (u *ImageUseCase) ProcessImage(ctx context.Context, req *images.ProcessImag
 					if metaErr := u.metadataRepository.UpdateMetadata(ctx, imageEntity); metaErr != nil {
 						slog.WarnContext(ctx, "failed to update image metadata in DynamoDB", slog.Any("err", metaErr))
 					}
+
+					u.publishImageStatusChanged(ctx, imageEntity)
 				}
 
 				// Return the non-retryable error so the worker can ACK the message
@@ -359,9 +964,16 @@ func (u *ImageUseCase) ProcessImage(ctx context.Context, req *images.ProcessImag
 			return err
 		}
 
-		req.StorageKey = imageData.ObjectStorageImageKey
+		storedKey, err := u.acquireOriginal(ctx, imageData.SHA256, imageEntity.ID.String(), imageData.ObjectStorageImageKey)
+		if err != nil {
+			telemetry.RegisterSpanError(span, err)
+			return err
+		}
+
 		imageEntity.MimeType = imageData.MimeType
-		imageEntity.ObjectStorageImageKey = imageData.ObjectStorageImageKey
+		imageEntity.ObjectStorageImageKey = storedKey
+		imageEntity.Checksum = imageData.Checksum
+		imageEntity.SHA256 = imageData.SHA256
 
 		// Update image entity with storage information
 		err = u.imageRepository.UpdateImage(ctx, imageEntity)
@@ -371,43 +983,146 @@ func (u *ImageUseCase) ProcessImage(ctx context.Context, req *images.ProcessImag
 			return err
 		}
 
+		u.dropDuplicateOriginal(ctx, imageData.ObjectStorageImageKey, storedKey)
+
 		// Update metadata in DynamoDB
 		if metaErr := u.metadataRepository.UpdateMetadata(ctx, imageEntity); metaErr != nil {
 			slog.WarnContext(ctx, "failed to update image metadata in DynamoDB", slog.Any("err", metaErr))
 		}
 	}
 
-	imageData, err := u.objectStorer.Get(ctx, req.StorageKey, u.imagesBucket)
+	// The stored key wins over the requested one, as deduplication may have moved the original
+	imageData, err := u.objectStorer.Get(ctx, imageEntity.ObjectStorageImageKey, u.imagesBucket)
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to get image", slog.Any("err", err), slog.String("bucket-name", u.imagesBucket))
 		telemetry.RegisterSpanError(span, err)
 		return err
 	}
 
-	var imageProcessed []byte
+	// Presigned uploads without a client checksum get theirs computed while the image is read
+	var originalData io.Reader = imageData
+	hasher := crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	if imageEntity.Checksum == "" {
+		originalData = io.TeeReader(imageData, hasher)
+	}
+
+	originalBytes, err := io.ReadAll(originalData)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to read image", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return err
+	}
+
+	// Presigned uploads land in the bucket without going through the API, so they are deduplicated here
+	if imageEntity.SHA256 == "" {
+		contentHash := sha256.Sum256(originalBytes)
+		imageEntity.SHA256 = hex.EncodeToString(contentHash[:])
+
+		uploadedKey := imageEntity.ObjectStorageImageKey
+		imageEntity.ObjectStorageImageKey, err = u.acquireOriginal(ctx, imageEntity.SHA256, imageEntity.ID.String(), uploadedKey)
+		if err != nil {
+			telemetry.RegisterSpanError(span, err)
+			return err
+		}
+
+		err = u.imageRepository.UpdateImage(ctx, imageEntity)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to update image with stored original", slog.Any("err", err))
+			telemetry.RegisterSpanError(span, err)
+			return err
+		}
+
+		u.dropDuplicateOriginal(ctx, uploadedKey, imageEntity.ObjectStorageImageKey)
+	}
+
+	imageEntity.OriginalProperties = u.inspectImage(ctx, originalBytes)
+
+	// Like the properties, a missing hash only keeps the image out of similarity searches
+	imageEntity.PerceptualHash, err = u.imageInspector.PerceptualHash(ctx, originalBytes)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to compute perceptual hash", slog.Any("err", err))
+	}
 
-	processedBytes, err := u.pipelineProcessor.ProcessPipeline(ctx, imageData, req.Transformations)
+	processed, err := u.pipelineProcessor.ProcessPipeline(ctx, bytes.NewReader(originalBytes), req.Transformations)
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to process image transformations", slog.Any("err", err))
 		telemetry.RegisterSpanError(span, err)
+
+		// Steps that can never fit this image won't succeed on a retry either
+		if errors.Is(err, images.ErrInvalidForImage) {
+			imageEntity.Status = images.StatusFailed
+			imageEntity.ErrorMessage = err.Error()
+			imageEntity.UpdatedAt = time.Now()
+
+			if updateErr := u.imageRepository.UpdateImage(ctx, imageEntity); updateErr != nil {
+				slog.ErrorContext(ctx, "failed to update image status to failed", slog.Any("err", updateErr))
+			} else {
+				if metaErr := u.metadataRepository.UpdateMetadata(ctx, imageEntity); metaErr != nil {
+					slog.WarnContext(ctx, "failed to update image metadata in DynamoDB", slog.Any("err", metaErr))
+				}
+
+				u.publishImageStatusChanged(ctx, imageEntity)
+			}
+
+			return images.NewNonRetryableError(err)
+		}
+
 		return err
 	}
 
 	slog.InfoContext(ctx, "image processed successfully", slog.String("image_id", req.ID), slog.Int("transformations", len(req.Transformations)))
-	imageProcessed = processedBytes
 
-	extension := strings.Split(imageEntity.ObjectStorageImageKey, ".")[1]
-	transformedImagePath := transformedImagePath + "/" + imageEntity.ID.String() + "." + extension
+	// The pipeline may have converted the image, so the key follows the output format
+	transformedImagePath := transformedImagePath + "/" + imageEntity.ID.String() + processed.Extension
 
-	err = u.objectStorer.Store(ctx, transformedImagePath, u.imagesBucket, imageEntity.MimeType, bytes.NewReader(imageProcessed))
+	err = u.objectStorer.Store(ctx, transformedImagePath, u.imagesBucket, processed.MimeType, bytes.NewReader(processed.Data))
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to store transformed image", slog.Any("err", err), slog.String("bucket-name", u.imagesBucket))
 		telemetry.RegisterSpanError(span, err)
 		return err
 	}
 
-	imageEntity.Status = "processed"
+	// Pages of an earlier run may outnumber the new ones, so they are cleared first
+	if imageEntity.PageCount > 0 {
+		if err := u.objectStorer.DeletePrefix(ctx, pagedImagePath+"/"+imageEntity.ID.String()+"/", u.imagesBucket); err != nil {
+			slog.WarnContext(ctx, "failed to delete previous image pages", slog.Any("err", err))
+		}
+	}
+
+	for i, page := range processed.Pages {
+		pageKey := imagePageKey(imageEntity.ID.String(), i+1, processed.Extension)
+		err = u.objectStorer.Store(ctx, pageKey, u.imagesBucket, processed.MimeType, bytes.NewReader(page))
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to store image page", slog.Any("err", err), slog.String("key", pageKey))
+			telemetry.RegisterSpanError(span, err)
+			return err
+		}
+	}
+
+	if imageEntity.Checksum == "" {
+		imageEntity.Checksum = encodeCRC32C(hasher.Sum32())
+	}
+
+	// A retry with different ops may have left a copy under another extension
+	previousTransformedKey := imageEntity.TransformedImageKey
+
+	imageEntity.Status = images.StatusProcessed
 	imageEntity.TransformedImageKey = transformedImagePath
+	imageEntity.TransformedMimeType = processed.MimeType
+	imageEntity.PageCount = len(processed.Pages)
+	imageEntity.TransformedProperties = u.inspectImage(ctx, processed.Data)
+
+	// Without a placeholder clients fall back to an empty box, which is no reason to fail the image
+	imageEntity.BlurHash, imageEntity.DominantColor, imageEntity.Palette = "", "", nil
+	placeholder, err := u.imageInspector.Placeholder(ctx, processed.Data)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to compute image placeholder", slog.Any("err", err))
+	} else {
+		imageEntity.BlurHash = placeholder.BlurHash
+		imageEntity.DominantColor = placeholder.DominantColor
+		imageEntity.Palette = placeholder.Palette
+	}
+
 	imageEntity.UpdatedAt = time.Now()
 
 	err = u.imageRepository.UpdateImage(ctx, imageEntity)
@@ -422,6 +1137,14 @@ func (u *ImageUseCase) ProcessImage(ctx context.Context, req *images.ProcessImag
 		slog.WarnContext(ctx, "failed to update image metadata in DynamoDB", slog.Any("err", metaErr))
 	}
 
+	if previousTransformedKey != "" && previousTransformedKey != transformedImagePath {
+		if err := u.objectStorer.Delete(ctx, previousTransformedKey, u.imagesBucket); err != nil {
+			slog.WarnContext(ctx, "failed to delete previous transformed image", slog.Any("err", err), slog.String("key", previousTransformedKey))
+		}
+	}
+
+	u.publishImageStatusChanged(ctx, imageEntity)
+
 	slog.InfoContext(ctx, "image processed successfully", slog.String("image_id", imageEntity.ID.String()))
 
 	return nil
@@ -451,6 +1174,76 @@ func (u *ImageUseCase) ListImages(ctx context.Context, req *images.ListImagesReq
 	return resp, nil
 }
 
+// FindImagesByChecksum lists the images sharing an original, newest first
+func (u *ImageUseCase) FindImagesByChecksum(ctx context.Context, req *images.FindImagesByChecksumRequest) ([]images.Image, error) {
+	ctx, span := tracer.Start(ctx, "ImageUseCase.FindImagesByChecksum", trace.WithAttributes(
+		attribute.String("image.checksum", req.Checksum),
+	))
+	defer span.End()
+
+	// Validate request
+	if err := ValidateStruct(req); err != nil {
+		slog.ErrorContext(ctx, "validation failed", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	found, err := u.imageRepository.FindImagesByChecksum(ctx, req.Checksum)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to find images by checksum", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	return found, nil
+}
+
+// FindSimilarImages lists the images whose original looks like the original of an image, closest first
+func (u *ImageUseCase) FindSimilarImages(ctx context.Context, req *images.FindSimilarImagesRequest) ([]images.SimilarImage, error) {
+	ctx, span := tracer.Start(ctx, "ImageUseCase.FindSimilarImages", trace.WithAttributes(
+		attribute.String("image.id", req.ID),
+	))
+	defer span.End()
+
+	// Validate request
+	if err := ValidateStruct(req); err != nil {
+		slog.ErrorContext(ctx, "validation failed", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	maxDistance := images.DefaultSimilarityDistance
+	if req.MaxDistance != nil {
+		maxDistance = *req.MaxDistance
+	}
+	limit := images.DefaultSimilarImagesLimit
+	if req.Limit != nil {
+		limit = *req.Limit
+	}
+
+	storedImage, err := u.imageRepository.FindImageByID(ctx, req.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to find image", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	if storedImage.PerceptualHash == "" {
+		err = images.ErrPerceptualHashNotAvailable
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	similarImages, err := u.imageRepository.FindSimilarImages(ctx, storedImage, maxDistance, limit)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to find similar images", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	return similarImages, nil
+}
+
 func (u *ImageUseCase) fetchAndStoreImage(ctx context.Context, req *images.ProcessImageRequest) (*images.Image, error) {
 	ctx, span := tracer.Start(ctx, "ImageUseCase.fetchAndStoreImage", trace.WithAttributes(
 		attribute.String("image.original_url", req.OriginalImageURL),
@@ -491,27 +1284,41 @@ func (u *ImageUseCase) fetchAndStoreImage(ctx context.Context, req *images.Proce
 		return nil, err
 	}
 
-	bodyData, err := io.ReadAll(resp.Body)
+	imageData, err := u.storeRawImage(ctx, req.ID, GetFileExtensionFromUrl(req.OriginalImageURL), resp.Body)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to store fetched image", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	return imageData, nil
+}
+
+// storeRawImage streams an original image into the bucket under raw-images/,
+// sniffing its MIME type and computing its CRC32C checksum on the way.
+// When extension is empty it is derived from the MIME type.
+func (u *ImageUseCase) storeRawImage(ctx context.Context, id string, extension string, body io.Reader) (*images.Image, error) {
+	ctx, span := tracer.Start(ctx, "ImageUseCase.storeRawImage", trace.WithAttributes(
+		attribute.String("image.id", id),
+	))
+	defer span.End()
+
+	mimeType, buffered, err := detectMIMEType(body)
 	if err != nil {
-		err = fmt.Errorf("failed to read response body: %w", err)
-		slog.ErrorContext(ctx, "failed to read response body", slog.Any("err", err))
+		slog.ErrorContext(ctx, "failed to read image body", slog.Any("err", err))
 		telemetry.RegisterSpanError(span, err)
 		return nil, err
 	}
 
-	mimeType := http.DetectContentType(bodyData)
 	if !allowedMIMETypes[mimeType] {
-		err = fmt.Errorf("disallowed MIME type: %s", mimeType)
+		err = fmt.Errorf("%w: %s", images.ErrDisallowedMIMEType, mimeType)
 		slog.ErrorContext(ctx, "disallowed MIME type", slog.String("mime_type", mimeType))
 		telemetry.RegisterSpanError(span, err)
 		return nil, err
 	}
 
-	slog.DebugContext(ctx, "Checking for file extension of MIME type or by .extension file request", slog.String("mimetype", mimeType), slog.String("image.url", req.OriginalImageURL))
-
-	extension := GetFileExtensionFromUrl(req.OriginalImageURL)
 	if extension == "" {
-		slog.DebugContext(ctx, "No file extension found in URL, determining from MIME type", slog.String("image.url", req.OriginalImageURL))
+		slog.DebugContext(ctx, "No file extension provided, determining from MIME type", slog.String("mimetype", mimeType))
 		extensions, err := mime.ExtensionsByType(mimeType)
 		if err != nil || len(extensions) == 0 {
 			err = fmt.Errorf("failed to get file extension for MIME type: %s", mimeType)
@@ -525,28 +1332,118 @@ func (u *ImageUseCase) fetchAndStoreImage(ctx context.Context, req *images.Proce
 
 	slog.InfoContext(ctx, "Determined file extension", slog.String("extension", extension))
 
-	// Calculate CRC32C checksum
-	checksum := calculateCRC32C(bodyData)
-	slog.InfoContext(ctx, "Calculated CRC32C checksum", slog.String("checksum", checksum))
+	rawImageKey := rawImagePath + "/" + id + extension
 
-	rawImageKey := rawImagePath + "/" + req.ID + extension
+	// Calculate CRC32C and SHA-256 checksums while the body is streamed to the bucket
+	hasher := crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	contentHasher := sha256.New()
 
-	err = u.objectStorer.Store(ctx, rawImageKey, u.imagesBucket, mimeType, bytes.NewBuffer(bodyData))
+	err = u.objectStorer.Store(ctx, rawImageKey, u.imagesBucket, mimeType, io.TeeReader(buffered, io.MultiWriter(hasher, contentHasher)))
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to store image", slog.Any("err", err), slog.String("bucket-name", u.imagesBucket))
 		telemetry.RegisterSpanError(span, err)
 		return nil, err
 	}
 
-	slog.InfoContext(ctx, "image stored successfully", slog.String("image_id", req.ID), slog.String("raw_image_key", rawImageKey))
+	checksum := encodeCRC32C(hasher.Sum32())
+	slog.InfoContext(ctx, "Calculated CRC32C checksum", slog.String("checksum", checksum))
+
+	contentHash := hex.EncodeToString(contentHasher.Sum(nil))
+
+	slog.InfoContext(ctx, "image stored successfully", slog.String("image_id", id), slog.String("raw_image_key", rawImageKey))
 
-	imageData := &images.Image{
+	// The hash is only known once the body was streamed, so callers drop a duplicate after acquiring the original
+	return &images.Image{
 		ObjectStorageImageKey: rawImageKey,
 		MimeType:              mimeType,
 		Checksum:              checksum,
+		SHA256:                contentHash,
+	}, nil
+}
+
+// acquireOriginal makes the image reference the stored original with the given SHA-256 and returns
+// the key it is stored under, which differs from key when identical bytes were stored before.
+// Acquiring again, as a redelivered message does, keeps a single reference.
+func (u *ImageUseCase) acquireOriginal(ctx context.Context, contentHash string, imageID string, key string) (string, error) {
+	storedKey, err := u.imageRepository.AcquireOriginal(ctx, contentHash, imageID, key)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to acquire stored original", slog.Any("err", err))
+		return "", err
 	}
 
-	return imageData, nil
+	if storedKey != key {
+		slog.InfoContext(ctx, "identical original already stored, reusing it",
+			slog.String("sha256", contentHash), slog.String("key", storedKey))
+	}
+
+	return storedKey, nil
+}
+
+// dropDuplicateOriginal deletes the copy stored under key when the original is stored under storedKey.
+// It runs once the image saved storedKey, so a retry before that still finds its own copy.
+func (u *ImageUseCase) dropDuplicateOriginal(ctx context.Context, key string, storedKey string) {
+	if key == storedKey {
+		return
+	}
+
+	if err := u.objectStorer.Delete(ctx, key, u.imagesBucket); err != nil {
+		slog.WarnContext(ctx, "failed to delete duplicate original", slog.Any("err", err), slog.String("key", key))
+	}
+}
+
+// releaseOriginal drops the reference of the image to the stored original with the given SHA-256,
+// deleting its object once no image uses it anymore
+func (u *ImageUseCase) releaseOriginal(ctx context.Context, contentHash string, imageID string) error {
+	key, err := u.imageRepository.ReleaseOriginal(ctx, contentHash, imageID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to release stored original", slog.Any("err", err))
+		return err
+	}
+
+	if key == "" {
+		return nil
+	}
+
+	return u.objectStorer.Delete(ctx, key, u.imagesBucket)
+}
+
+// detectMIMEType sniffs the MIME type of body without consuming it.
+// The returned reader must be used in place of body afterwards.
+func detectMIMEType(body io.Reader) (string, io.Reader, error) {
+	// http.DetectContentType only considers the first 512 bytes
+	buffered := bufio.NewReaderSize(body, 512)
+	head, err := buffered.Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", nil, fmt.Errorf("failed to read image body: %w", err)
+	}
+
+	return http.DetectContentType(head), buffered, nil
+}
+
+// publishProcessImageRequest enqueues the image for the worker to process
+func (u *ImageUseCase) publishProcessImageRequest(ctx context.Context, image *images.Image, transformations []images.TransformationRequest) error {
+	processReq := &images.ProcessImageRequest{
+		ID:               image.ID.String(),
+		OriginalImageURL: image.OriginalImageURL,
+		StorageKey:       image.ObjectStorageImageKey,
+		Transformations:  transformations,
+	}
+
+	payload, err := json.Marshal(processReq)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to marshal process image request", slog.Any("err", err))
+		return err
+	}
+
+	message := message.NewMessageWithContext(ctx, watermill.NewUUID(), payload)
+
+	err = u.publisher.Publish(u.imageTopic, message)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to publish image request", slog.Any("err", err))
+		return err
+	}
+
+	return nil
 }
 
 func (u *ImageUseCase) GetImageRealtimeUpdate(ctx context.Context, id string) (chan *images.Image, func() error, error) {
@@ -562,7 +1459,7 @@ func (u *ImageUseCase) GetImageRealtimeUpdate(ctx context.Context, id string) (c
 
 	ctx, cancel := context.WithCancel(ctx)
 
-	ch, err := u.subscriber.Subscribe(ctx, u.imageTopic)
+	events, unlisten, err := u.imageEvents.listen(ctx)
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to subscribe to events", slog.Any("err", err))
 		telemetry.RegisterSpanError(span, err)
@@ -570,29 +1467,34 @@ func (u *ImageUseCase) GetImageRealtimeUpdate(ctx context.Context, id string) (c
 		return nil, nil, err
 	}
 
-	imagesChan := make(chan *images.Image, len(ch)+1)
+	imagesChan := make(chan *images.Image, imageEventListenerBuffer)
 
 	go func() {
+		defer close(imagesChan)
+		defer unlisten()
+
 		// Send the current state first
 		imagesChan <- currentImage
 
-		// Then listen for updates
-		for msg := range ch {
-			image := images.Image{}
-
-			err := json.Unmarshal(msg.Payload, &image)
-			if err != nil {
-				slog.ErrorContext(ctx, "failed to unmarshal image", slog.Any("err", err))
-				msg.Nack()
-				continue
-			}
+		// Then listen for updates of this image only
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case image, ok := <-events:
+				if !ok {
+					return
+				}
+				if image.ID != currentImage.ID {
+					continue
+				}
 
-			if image.ID.String() != id {
-				msg.Nack()
-				continue
+				select {
+				case imagesChan <- image:
+				case <-ctx.Done():
+					return
+				}
 			}
-
-			imagesChan <- &image
 		}
 	}()
 
@@ -619,7 +1521,7 @@ func (u *ImageUseCase) GetAllImagesUpdates(ctx context.Context) (chan *images.Im
 
 	ctx, cancel := context.WithCancel(ctx)
 
-	ch, err := u.subscriber.Subscribe(ctx, u.imageTopic)
+	events, unlisten, err := u.imageEvents.listen(ctx)
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to subscribe to events", slog.Any("err", err))
 		telemetry.RegisterSpanError(span, err)
@@ -627,26 +1529,32 @@ func (u *ImageUseCase) GetAllImagesUpdates(ctx context.Context) (chan *images.Im
 		return nil, nil, err
 	}
 
-	imagesChan := make(chan *images.Image, len(ch)+len(allImagesResp.Data))
+	imagesChan := make(chan *images.Image, imageEventListenerBuffer+len(allImagesResp.Data))
 
 	go func() {
+		defer close(imagesChan)
+		defer unlisten()
+
 		// Send all current images first
 		for i := range allImagesResp.Data {
 			imagesChan <- &allImagesResp.Data[i]
 		}
 
 		// Then listen for updates
-		for msg := range ch {
-			image := images.Image{}
-
-			err := json.Unmarshal(msg.Payload, &image)
-			if err != nil {
-				slog.ErrorContext(ctx, "failed to unmarshal image", slog.Any("err", err))
-				msg.Nack()
-				continue
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case image, ok := <-events:
+				if !ok {
+					return
+				}
+				select {
+				case imagesChan <- image:
+				case <-ctx.Done():
+					return
+				}
 			}
-
-			imagesChan <- &image
 		}
 	}()
 
@@ -656,6 +1564,53 @@ func (u *ImageUseCase) GetAllImagesUpdates(ctx context.Context) (chan *images.Im
 	}, nil
 }
 
+// nopSeekCloser turns an in-memory image into an ImageContent body
+type nopSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopSeekCloser) Close() error { return nil }
+
+// inspectImage reads the properties of an image, which are only descriptive,
+// so an image they can't be read from is still processed and stored without them
+func (u *ImageUseCase) inspectImage(ctx context.Context, data []byte) *images.ImageProperties {
+	properties, err := u.imageInspector.Inspect(ctx, data)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to inspect image", slog.Any("err", err))
+		return nil
+	}
+
+	return properties
+}
+
+// imageVariantKey returns the storage key of the requested copy of an image, or of one of its pages
+func imageVariantKey(image *images.Image, variant images.ImageVariant, page int) (string, error) {
+	key := image.ObjectStorageImageKey
+	if variant == images.ImageVariantTransformed {
+		key = image.TransformedImageKey
+	}
+
+	// Images awaiting an upload already have a key, but nothing is stored under it yet
+	if key == "" || image.Status == images.StatusAwaitingUpload {
+		return "", images.ErrImageContentNotAvailable
+	}
+
+	if page > 0 {
+		// Only transformed images loaded with a page range have pages of their own
+		if variant != images.ImageVariantTransformed || page > image.PageCount {
+			return "", images.ErrImageContentNotAvailable
+		}
+		return imagePageKey(image.ID.String(), page, filepath.Ext(key)), nil
+	}
+
+	return key, nil
+}
+
+// imagePageKey returns the storage key of a page of a transformed image, counting pages from 1
+func imagePageKey(id string, page int, extension string) string {
+	return pagedImagePath + "/" + id + "/" + strconv.Itoa(page) + extension
+}
+
 func GetFileExtensionFromUrl(rawUrl string) string {
 	u, err := url.Parse(rawUrl)
 	if err != nil {
@@ -668,16 +1623,8 @@ func GetFileExtensionFromUrl(rawUrl string) string {
 	return "." + u.Path[pos+1:len(u.Path)]
 }
 
-// calculateCRC32C computes the CRC32C checksum of data and returns it as a base64-encoded string
-func calculateCRC32C(data []byte) string {
-	hasher := crc32.New(crc32.MakeTable(crc32.Castagnoli))
-
-	// Write the data to the hasher
-	hasher.Write(data)
-
-	// Get the 32-bit CRC32C checksum
-	checksumUint32 := hasher.Sum32()
-
+// encodeCRC32C encodes a CRC32C checksum as a base64 string
+func encodeCRC32C(checksumUint32 uint32) string {
 	// Convert the uint32 checksum to a byte slice
 	checksumBytes := make([]byte, 4)
 	binary.BigEndian.PutUint32(checksumBytes, checksumUint32)
@@ -1,10 +1,26 @@
 package images
 
+import "time"
+
 type ListImagesRequest struct {
 	Page  int `query:"page" validate:"required,gte=1"`
 	Limit int `query:"limit" validate:"required,gte=1,lte=100"`
 }
 
+// FindImagesByChecksumRequest looks images up by the SHA-256 hex digest or the CRC32C checksum of their original
+type FindImagesByChecksumRequest struct {
+	Checksum string `query:"checksum" validate:"required,max=64"`
+}
+
+// FindSimilarImagesRequest looks up the images whose original looks like the original of an image.
+// MaxDistance and Limit fall back to DefaultSimilarityDistance and DefaultSimilarImagesLimit when unset.
+// Past 11 of the 64 bits unrelated images start to match, and the indexed bands stop being selective.
+type FindSimilarImagesRequest struct {
+	ID          string `param:"id" validate:"required,uuid"`
+	MaxDistance *int   `query:"max_distance" validate:"omitempty,gte=0,lte=11"`
+	Limit       *int   `query:"limit" validate:"omitempty,gte=1,lte=100"`
+}
+
 type ListImagesResponse struct {
 	Page  int     `json:"page"`
 	Limit int     `json:"limit"`
@@ -25,9 +41,43 @@ type DeleteImageRequest struct {
 	ID string `validate:"required,uuid"`
 }
 
+// UploadImageRequest describes an image whose bytes are sent directly to the API
+// instead of being fetched from a URL by the worker.
+type UploadImageRequest struct {
+	Filename        string                  `validate:"required"`
+	Transformations []TransformationRequest `validate:"required,min=1,dive"`
+}
+
+// CreateUploadRequest reserves an image whose bytes the client puts directly in the bucket
+// through a presigned URL. Filename is only used to pick the object extension.
+type CreateUploadRequest struct {
+	Filename        string
+	Transformations []TransformationRequest `validate:"required,min=1,dive"`
+}
+
+type CreateUploadResponse struct {
+	ID        string    `json:"id"`
+	UploadURL string    `json:"upload_url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type CompleteUploadRequest struct {
+	ID string `validate:"required,uuid"`
+}
+
+// RenderImageRequest asks for the original image run through an ad hoc pipeline, see ParseRenderOps.
+// Expires is a unix timestamp and Signature must come from SignRender.
+type RenderImageRequest struct {
+	ID        string `validate:"required,uuid"`
+	Ops       string `validate:"required"`
+	Expires   int64  `validate:"required"`
+	Signature string `validate:"required"`
+}
+
 type ProcessImageRequest struct {
-	ID               string `validate:"required,uuid"`
-	OriginalImageURL string `validate:"required,url"`
+	ID string `validate:"required,uuid"`
+	// OriginalImageURL is only needed when the image is not stored yet
+	OriginalImageURL string `validate:"required_without=StorageKey,omitempty,url"`
 	StorageKey       string
 	Transformations  []TransformationRequest `validate:"required,min=1,dive"`
 }
@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/google/uuid"
@@ -35,6 +36,17 @@ func (t *TransformationList) Scan(value interface{}) error {
 	return json.Unmarshal(bytes, t)
 }
 
+// Image processing statuses
+const (
+	// StatusAwaitingUpload marks an image reserved for a presigned upload the client has not completed yet
+	StatusAwaitingUpload = "awaiting_upload"
+	StatusPending        = "pending"
+	StatusProcessing     = "processing"
+	StatusProcessed      = "processed"
+	StatusFailed         = "failed"
+	StatusDeleted        = "deleted"
+)
+
 type Image struct {
 	ID                    uuid.UUID          `json:"id"`
 	OriginalImageURL      string             `json:"original_image_url"`
@@ -42,26 +54,104 @@ type Image struct {
 	MimeType              string             `json:"mime_type"`
 	Status                string             `json:"status"`
 	TransformedImageKey   string             `json:"transformed_image_key"`
+	TransformedMimeType   string             `json:"transformed_mime_type"`
+	PageCount             int                `json:"page_count"`
 	Checksum              string             `json:"checksum"`
+	SHA256                string             `json:"sha256"`
+	PerceptualHash        string             `json:"perceptual_hash,omitempty"`
 	ErrorMessage          string             `json:"error_message,omitempty"`
 	Transformations       TransformationList `json:"transformations"`
+	BlurHash              string             `json:"blurhash,omitempty"`
+	DominantColor         string             `json:"dominant_color,omitempty"`
+	Palette               []string           `json:"palette,omitempty"`
+	OriginalProperties    *ImageProperties   `json:"original_properties,omitempty"`
+	TransformedProperties *ImageProperties   `json:"transformed_properties,omitempty"`
 	UpdatedAt             time.Time          `json:"updated_at"`
 	CreatedAt             time.Time          `json:"created_at"`
 }
 
+// ImagePlaceholder is what clients show while an image loads
+type ImagePlaceholder struct {
+	// BlurHash is decoded by clients into a blurred preview, see https://blurha.sh
+	BlurHash string `json:"blurhash"`
+
+	// DominantColor is the #rrggbb colour covering most of the image
+	DominantColor string `json:"dominant_color"`
+
+	// Palette lists up to five #rrggbb colours, the one covering most of the image first
+	Palette []string `json:"palette"`
+}
+
+// ImageProperties describes a stored copy of an image, read from its header
+// so clients can lay it out without downloading it
+type ImageProperties struct {
+	Width int `json:"width"`
+
+	// Height is the height of a single page or frame
+	Height int `json:"height"`
+
+	Size int64 `json:"size"`
+
+	// ColorSpace is the libvips interpretation of the pixels, such as srgb, b-w or cmyk
+	ColorSpace string `json:"color_space"`
+
+	BitDepth int  `json:"bit_depth"`
+	HasAlpha bool `json:"has_alpha"`
+
+	// Pages counts the pages of a document or the frames of an animation
+	Pages int `json:"pages"`
+
+	Orientation int `json:"orientation,omitempty"`
+
+	// ICCProfile is the description of the embedded ICC profile
+	ICCProfile string `json:"icc_profile,omitempty"`
+
+	// EXIF maps EXIF tags, such as ifd0-Make, to their values
+	EXIF map[string]string `json:"exif,omitempty"`
+}
+
+// ImageVariant selects which stored copy of an image is served
+type ImageVariant string
+
+const (
+	ImageVariantOriginal    ImageVariant = "original"
+	ImageVariantTransformed ImageVariant = "transformed"
+)
+
+// ImageContent is a stored image ready to be served
+type ImageContent struct {
+	Body       io.ReadSeekCloser
+	MimeType   string
+	ETag       string
+	ModifiedAt time.Time
+}
+
+// ProcessedImage is the output of the transformation pipeline, which may be
+// encoded in a different format than the original
+type ProcessedImage struct {
+	Data      []byte
+	MimeType  string
+	Extension string
+	// Pages holds every page encoded on its own when a page range was loaded, Data is then the first one
+	Pages [][]byte
+}
+
 // ImageMetadata represents metadata stored in DynamoDB for fast querying
 type ImageMetadata struct {
-	ID                    string    `json:"id"`
-	OriginalImageURL      string    `json:"original_image_url"`
-	ObjectStorageImageKey string    `json:"object_storage_image_key"`
-	TransformedImageKey   string    `json:"transformed_image_key"`
-	MimeType              string    `json:"mime_type"`
-	Status                string    `json:"status"`
-	Checksum              string    `json:"checksum"`
-	ErrorMessage          string    `json:"error_message,omitempty"`
-	TransformationCount   int       `json:"transformation_count"`
-	UpdatedAt             time.Time `json:"updated_at"`
-	CreatedAt             time.Time `json:"created_at"`
+	ID                    string           `json:"id"`
+	OriginalImageURL      string           `json:"original_image_url"`
+	ObjectStorageImageKey string           `json:"object_storage_image_key"`
+	TransformedImageKey   string           `json:"transformed_image_key"`
+	MimeType              string           `json:"mime_type"`
+	TransformedMimeType   string           `json:"transformed_mime_type"`
+	Status                string           `json:"status"`
+	Checksum              string           `json:"checksum"`
+	ErrorMessage          string           `json:"error_message,omitempty"`
+	TransformationCount   int              `json:"transformation_count"`
+	OriginalProperties    *ImageProperties `json:"original_properties,omitempty"`
+	TransformedProperties *ImageProperties `json:"transformed_properties,omitempty"`
+	UpdatedAt             time.Time        `json:"updated_at"`
+	CreatedAt             time.Time        `json:"created_at"`
 }
 
 // ToMetadata converts an Image to ImageMetadata
@@ -72,10 +162,13 @@ func (i *Image) ToMetadata() *ImageMetadata {
 		ObjectStorageImageKey: i.ObjectStorageImageKey,
 		TransformedImageKey:   i.TransformedImageKey,
 		MimeType:              i.MimeType,
+		TransformedMimeType:   i.TransformedMimeType,
 		Status:                i.Status,
 		Checksum:              i.Checksum,
 		ErrorMessage:          i.ErrorMessage,
 		TransformationCount:   len(i.Transformations),
+		OriginalProperties:    i.OriginalProperties,
+		TransformedProperties: i.TransformedProperties,
 		UpdatedAt:             i.UpdatedAt,
 		CreatedAt:             i.CreatedAt,
 	}
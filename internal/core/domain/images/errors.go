@@ -1,6 +1,70 @@
 package images
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrDisallowedMIMEType is returned when an image is not one of the supported formats
+var ErrDisallowedMIMEType = errors.New("disallowed MIME type")
+
+// ErrImageNotAwaitingUpload is returned when completing an upload for an image that was not reserved for one
+var ErrImageNotAwaitingUpload = errors.New("image is not awaiting an upload")
+
+// ErrImageContentNotAvailable is returned when the requested copy of an image was not stored yet
+var ErrImageContentNotAvailable = errors.New("image content not available")
+
+// ErrUploadNotFound is returned when completing an upload whose object was never put in the bucket
+var ErrUploadNotFound = errors.New("uploaded object not found")
+
+// ErrUploadExpired is recorded on an image whose presigned upload was never completed
+var ErrUploadExpired = errors.New("upload was not completed before it expired")
+
+// ErrInvalidForImage is matched by the errors of steps that can never succeed on a given image,
+// such as a crop outside of it, so retrying is pointless and the request is at fault
+var ErrInvalidForImage = errors.New("transformation is invalid for this image")
+
+// invalidForImageError is an error that errors.Is also reports as ErrInvalidForImage
+type invalidForImageError struct {
+	msg string
+}
+
+func newInvalidForImageError(msg string) error {
+	return &invalidForImageError{msg: msg}
+}
+
+func (e *invalidForImageError) Error() string {
+	return e.msg
+}
+
+func (e *invalidForImageError) Is(target error) bool {
+	return target == ErrInvalidForImage
+}
+
+// ErrCropOutOfBounds is returned when an explicit crop area does not fit inside the image
+var ErrCropOutOfBounds = newInvalidForImageError("crop area is outside the image")
+
+// ErrWatermarkNotFound is returned when the image a watermark references is not stored
+var ErrWatermarkNotFound = newInvalidForImageError("watermark image not found")
+
+// ErrTrimEmpty is returned when trimming would remove the whole image because all of it matches the background
+var ErrTrimEmpty = newInvalidForImageError("image is entirely background, nothing is left after trimming")
+
+// ErrCanvasTooLarge is returned when extending an image, or a caption drawn onto it, would be larger than MaxCanvasSize
+var ErrCanvasTooLarge = newInvalidForImageError("canvas is too large")
+
+// ErrFrameOutOfRange is returned when a frame past the last frame of an animation is asked for
+var ErrFrameOutOfRange = newInvalidForImageError("frame is out of range")
+
+// ErrPageOutOfRange is returned when a page past the last page of a document is asked for
+var ErrPageOutOfRange = newInvalidForImageError("page is out of range")
+
+// ErrAnimationFramesDiffer is returned when a step leaves the frames of an animation with different sizes,
+// which can't be stacked back into an animation
+var ErrAnimationFramesDiffer = newInvalidForImageError("animation frames have different sizes")
+
+// ErrPerceptualHashNotAvailable is returned when similar images are looked up for an image that was not processed yet
+var ErrPerceptualHashNotAvailable = errors.New("image has no perceptual hash yet")
 
 // NonRetryableError represents an error that should not be retried
 // When this error is returned, the message should be ACKed instead of NACKed
@@ -0,0 +1,43 @@
+package images
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrInvalidForImage(t *testing.T) {
+	invalidForImage := []error{
+		ErrCropOutOfBounds,
+		ErrWatermarkNotFound,
+		ErrTrimEmpty,
+		ErrCanvasTooLarge,
+		ErrFrameOutOfRange,
+		ErrPageOutOfRange,
+		ErrAnimationFramesDiffer,
+	}
+
+	for _, err := range invalidForImage {
+		t.Run(err.Error(), func(t *testing.T) {
+			wrapped := fmt.Errorf("transformation failed at step 2 (crop): %w", err)
+
+			if !errors.Is(wrapped, ErrInvalidForImage) {
+				t.Errorf("errors.Is(%v, ErrInvalidForImage) = false, want true", wrapped)
+			}
+			if !errors.Is(wrapped, err) {
+				t.Errorf("errors.Is(%v, %v) = false, want true", wrapped, err)
+			}
+		})
+	}
+
+	// Each error still only matches itself
+	if errors.Is(ErrCropOutOfBounds, ErrTrimEmpty) {
+		t.Error("errors.Is(ErrCropOutOfBounds, ErrTrimEmpty) = true, want false")
+	}
+
+	for _, err := range []error{ErrDisallowedMIMEType, ErrUploadNotFound, ErrInvalidRenderOps, errors.New("vips failed")} {
+		if errors.Is(err, ErrInvalidForImage) {
+			t.Errorf("errors.Is(%v, ErrInvalidForImage) = true, want false", err)
+		}
+	}
+}
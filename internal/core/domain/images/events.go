@@ -0,0 +1,10 @@
+package images
+
+import "time"
+
+// ImageStatusChangedEvent is published every time an image changes state,
+// so that realtime listeners can follow it without reading the job queue
+type ImageStatusChangedEvent struct {
+	Image      Image     `json:"image"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
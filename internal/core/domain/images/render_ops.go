@@ -0,0 +1,145 @@
+package images
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidRenderOps is returned when a render ops string can't be turned into a valid pipeline
+var ErrInvalidRenderOps = errors.New("invalid render ops")
+
+// RenderArgSize stands for a WxH size among the render arguments of a transformer, filling in width and height.
+// Either side may be left out, and a lone number is the width.
+const RenderArgSize = "size"
+
+// renderOpStringArgs are config keys whose values are kept as given, so a caption such as "2024" stays text
+var renderOpStringArgs = map[string]bool{
+	"text": true,
+}
+
+// ParseRenderOps parses an imgproxy-style ops string such as "resize:300x200,blur:2,grayscale".
+// Ops are separated by commas and their arguments by colons.
+func ParseRenderOps(ops string) ([]TransformationRequest, error) {
+	if strings.TrimSpace(ops) == "" {
+		return nil, fmt.Errorf("%w: no ops given", ErrInvalidRenderOps)
+	}
+
+	transformations := []TransformationRequest{}
+
+	for _, op := range strings.Split(ops, ",") {
+		parts := strings.Split(strings.TrimSpace(op), ":")
+
+		name := strings.ToLower(parts[0])
+		if name == "" {
+			return nil, fmt.Errorf("%w: empty op in %q", ErrInvalidRenderOps, ops)
+		}
+
+		config := map[string]any{}
+		positional := 0
+
+		for _, arg := range parts[1:] {
+			if key, value, ok := strings.Cut(arg, "="); ok {
+				config[key] = parseRenderOpArg(key, value)
+				continue
+			}
+
+			// The transformers register the keys their arguments fill in, see render_args.go
+			keys := renderOpPositionalArgs[name]
+			if positional >= len(keys) {
+				return nil, fmt.Errorf("%w: unexpected argument %q for op %s", ErrInvalidRenderOps, arg, name)
+			}
+
+			key := keys[positional]
+			positional++
+
+			if key == RenderArgSize {
+				width, height, _ := strings.Cut(arg, "x")
+				if width != "" {
+					config["width"] = parseRenderOpValue(width)
+				}
+				if height != "" {
+					config["height"] = parseRenderOpValue(height)
+				}
+				continue
+			}
+
+			config[key] = parseRenderOpArg(key, arg)
+		}
+
+		transformations = append(transformations, TransformationRequest{
+			Name:   name,
+			Config: config,
+		})
+	}
+
+	return transformations, nil
+}
+
+// parseRenderOpArg types the argument given for key, leaving string-only keys untouched
+func parseRenderOpArg(key, raw string) any {
+	if renderOpStringArgs[key] {
+		return raw
+	}
+
+	return parseRenderOpValue(raw)
+}
+
+// parseRenderOpValue types a raw argument the same way a JSON payload would be decoded
+func parseRenderOpValue(raw string) any {
+	if number, err := strconv.ParseFloat(raw, 64); err == nil {
+		return number
+	}
+
+	if boolean, err := strconv.ParseBool(raw); err == nil {
+		return boolean
+	}
+
+	return raw
+}
+
+// CanonicalizeTransformations renders a transformation list in a stable form,
+// so equivalent lists produce the same bytes no matter how they were written.
+func CanonicalizeTransformations(transformations []TransformationRequest) ([]byte, error) {
+	canonical := make([]TransformationRequest, 0, len(transformations))
+
+	for _, tx := range transformations {
+		config := tx.Config
+		if config == nil {
+			config = map[string]any{}
+		}
+
+		// An alias renders the same as the name, so both share a cache key
+		canonical = append(canonical, TransformationRequest{
+			Name:   TransformationName(tx.Name),
+			Config: config,
+		})
+	}
+
+	// encoding/json sorts map keys, which makes the output stable
+	return json.Marshal(canonical)
+}
+
+// TransformationName returns the name of the transformation requested by name or by one of its aliases
+func TransformationName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if canonical, ok := transformationAliases[name]; ok {
+		return canonical
+	}
+	return name
+}
+
+// HashTransformations returns the hex SHA-256 of the canonicalized transformation list
+func HashTransformations(transformations []TransformationRequest) (string, error) {
+	canonical, err := CanonicalizeTransformations(transformations)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
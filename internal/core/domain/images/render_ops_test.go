@@ -0,0 +1,112 @@
+package images
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseRenderOps(t *testing.T) {
+	tests := []struct {
+		name    string
+		ops     string
+		want    []TransformationRequest
+		wantErr bool
+	}{
+		{
+			name: "size",
+			ops:  "resize:300x200:pad,grayscale",
+			want: []TransformationRequest{
+				{Name: "resize", Config: map[string]any{"width": 300.0, "height": 200.0, "mode": "pad"}},
+				{Name: "grayscale", Config: map[string]any{}},
+			},
+		},
+		{
+			name: "height only",
+			ops:  "crop:x100",
+			want: []TransformationRequest{{Name: "crop", Config: map[string]any{"height": 100.0}}},
+		},
+		{
+			name: "width alone",
+			ops:  "pad:640:south",
+			want: []TransformationRequest{{Name: "pad", Config: map[string]any{"width": 640.0, "gravity": "south"}}},
+		},
+		{
+			name: "alias takes the arguments of its name",
+			ops:  "poster:2",
+			want: []TransformationRequest{{Name: "poster", Config: map[string]any{"index": 2.0}}},
+		},
+		{
+			name: "alias with its own arguments",
+			ops:  "density:300",
+			want: []TransformationRequest{{Name: "density", Config: map[string]any{"dpi": 300.0}}},
+		},
+		{
+			name: "keyed arguments",
+			ops:  "blur:sigma=2,text:2024",
+			want: []TransformationRequest{
+				{Name: "blur", Config: map[string]any{"sigma": 2.0}},
+				{Name: "text", Config: map[string]any{"text": "2024"}},
+			},
+		},
+		{name: "too many arguments", ops: "blur:2:3", wantErr: true},
+		{name: "no arguments taken", ops: "grayscale:1", wantErr: true},
+		{name: "empty op", ops: "resize:300,,grayscale", wantErr: true},
+		{name: "empty", ops: " ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRenderOps(tt.ops)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRenderOps(%q) error = %v, wantErr %v", tt.ops, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidRenderOps) {
+				t.Errorf("ParseRenderOps(%q) error = %v, want %v", tt.ops, err, ErrInvalidRenderOps)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRenderOps(%q) = %v, want %v", tt.ops, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHashTransformationsResolvesAliases(t *testing.T) {
+	tests := []struct {
+		name  string
+		ops   string
+		alias string
+	}{
+		{name: "watermark", ops: "watermark:5b0f1c2e-8a43-4a57-9b8e-2f1e6d3c4b5a:south", alias: "overlay:5b0f1c2e-8a43-4a57-9b8e-2f1e6d3c4b5a:south"},
+		{name: "modulate", ops: "modulate:1.2", alias: "adjust:1.2"},
+		{name: "format", ops: "format:webp:80", alias: "Encode:webp:80"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := hashRenderOps(t, tt.ops)
+			if got := hashRenderOps(t, tt.alias); got != want {
+				t.Errorf("hash of %q = %s, want the hash of %q, %s", tt.alias, got, tt.ops, want)
+			}
+		})
+	}
+
+	if hashRenderOps(t, "blur:2") == hashRenderOps(t, "sharpen:2") {
+		t.Error("different transformations share a hash")
+	}
+}
+
+func hashRenderOps(t *testing.T, ops string) string {
+	t.Helper()
+
+	transformations, err := ParseRenderOps(ops)
+	if err != nil {
+		t.Fatalf("ParseRenderOps(%q) error = %v", ops, err)
+	}
+
+	hash, err := HashTransformations(transformations)
+	if err != nil {
+		t.Fatalf("HashTransformations() error = %v", err)
+	}
+	return hash
+}
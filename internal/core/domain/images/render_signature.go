@@ -0,0 +1,53 @@
+package images
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrInvalidRenderSignature is returned when a render URL was not signed with our key for these ops
+	ErrInvalidRenderSignature = errors.New("invalid render signature")
+	// ErrRenderURLExpired is returned when a signed render URL is used after its expiry
+	ErrRenderURLExpired = errors.New("render URL expired")
+)
+
+// SignRender signs a render of the image with the given transformations until expiresAt.
+// The transformations are canonicalized first, so any spelling of the same ops shares a signature.
+func SignRender(key []byte, id string, transformations []TransformationRequest, expiresAt time.Time) (string, error) {
+	canonical, err := CanonicalizeTransformations(transformations)
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id))
+	mac.Write([]byte{'\n'})
+	mac.Write([]byte(strconv.FormatInt(expiresAt.Unix(), 10)))
+	mac.Write([]byte{'\n'})
+	mac.Write(canonical)
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// VerifyRender checks a signature produced by SignRender and that it has not expired yet
+func VerifyRender(key []byte, id string, transformations []TransformationRequest, expiresAt time.Time, signature string) error {
+	expected, err := SignRender(key, id, transformations, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidRenderSignature
+	}
+
+	if time.Now().After(expiresAt) {
+		return ErrRenderURLExpired
+	}
+
+	return nil
+}
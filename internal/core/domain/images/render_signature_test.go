@@ -0,0 +1,166 @@
+package images
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestVerifyRender(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	id := "5b0f1c2e-8a43-4a57-9b8e-2f1e6d3c4b5a"
+	ops := []TransformationRequest{
+		{Name: "resize", Config: map[string]any{"width": 300, "height": 200}},
+		{Name: "grayscale"},
+	}
+	expiresAt := time.Now().Add(time.Hour)
+
+	signature, err := SignRender(key, id, ops, expiresAt)
+	if err != nil {
+		t.Fatalf("SignRender() error = %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		key             []byte
+		id              string
+		transformations []TransformationRequest
+		expiresAt       time.Time
+		signature       string
+		want            error
+	}{
+		{
+			name:            "valid",
+			key:             key,
+			id:              id,
+			transformations: ops,
+			expiresAt:       expiresAt,
+			signature:       signature,
+		},
+		{
+			name: "same ops spelled differently",
+			key:  key,
+			id:   id,
+			transformations: []TransformationRequest{
+				{Name: " Resize ", Config: map[string]any{"height": 200, "width": 300}},
+				{Name: "GRAYSCALE", Config: map[string]any{}},
+			},
+			expiresAt: expiresAt,
+			signature: signature,
+		},
+		{
+			name: "tampered ops",
+			key:  key,
+			id:   id,
+			transformations: []TransformationRequest{
+				{Name: "resize", Config: map[string]any{"width": 3000, "height": 2000}},
+				{Name: "grayscale"},
+			},
+			expiresAt: expiresAt,
+			signature: signature,
+			want:      ErrInvalidRenderSignature,
+		},
+		{
+			name:            "dropped op",
+			key:             key,
+			id:              id,
+			transformations: ops[:1],
+			expiresAt:       expiresAt,
+			signature:       signature,
+			want:            ErrInvalidRenderSignature,
+		},
+		{
+			name:            "other image",
+			key:             key,
+			id:              "0d9c8b7a-6f5e-4d3c-2b1a-0f9e8d7c6b5a",
+			transformations: ops,
+			expiresAt:       expiresAt,
+			signature:       signature,
+			want:            ErrInvalidRenderSignature,
+		},
+		{
+			name:            "extended expiry",
+			key:             key,
+			id:              id,
+			transformations: ops,
+			expiresAt:       expiresAt.Add(24 * time.Hour),
+			signature:       signature,
+			want:            ErrInvalidRenderSignature,
+		},
+		{
+			name:            "wrong key",
+			key:             []byte("fedcba9876543210fedcba9876543210"),
+			id:              id,
+			transformations: ops,
+			expiresAt:       expiresAt,
+			signature:       signature,
+			want:            ErrInvalidRenderSignature,
+		},
+		{
+			name:            "empty signature",
+			key:             key,
+			id:              id,
+			transformations: ops,
+			expiresAt:       expiresAt,
+			signature:       "",
+			want:            ErrInvalidRenderSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyRender(tt.key, tt.id, tt.transformations, tt.expiresAt, tt.signature)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("VerifyRender() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyRenderExpired(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	id := "5b0f1c2e-8a43-4a57-9b8e-2f1e6d3c4b5a"
+	ops := []TransformationRequest{{Name: "grayscale"}}
+
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		want      error
+	}{
+		{name: "expires later", expiresAt: time.Now().Add(time.Minute)},
+		{name: "expired a second ago", expiresAt: time.Now().Add(-time.Second), want: ErrRenderURLExpired},
+		{name: "expired a day ago", expiresAt: time.Now().Add(-24 * time.Hour), want: ErrRenderURLExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signature, err := SignRender(key, id, ops, tt.expiresAt)
+			if err != nil {
+				t.Fatalf("SignRender() error = %v", err)
+			}
+
+			err = VerifyRender(key, id, ops, tt.expiresAt, signature)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("VerifyRender() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyRenderExpiredWithWrongKey(t *testing.T) {
+	// A forged signature must not learn whether its expiry passed
+	key := []byte("0123456789abcdef0123456789abcdef")
+	id := "5b0f1c2e-8a43-4a57-9b8e-2f1e6d3c4b5a"
+	ops := []TransformationRequest{{Name: "grayscale"}}
+	expiresAt := time.Now().Add(-time.Hour)
+
+	signature, err := SignRender([]byte("fedcba9876543210fedcba9876543210"), id, ops, expiresAt)
+	if err != nil {
+		t.Fatalf("SignRender() error = %v", err)
+	}
+
+	err = VerifyRender(key, id, ops, expiresAt, signature)
+	if !errors.Is(err, ErrInvalidRenderSignature) {
+		t.Errorf("VerifyRender() error = %v, want %v", err, ErrInvalidRenderSignature)
+	}
+}
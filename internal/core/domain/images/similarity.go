@@ -0,0 +1,38 @@
+package images
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+)
+
+// Near-duplicate search defaults, for requests that leave them unset
+const (
+	DefaultSimilarityDistance = 8
+	DefaultSimilarImagesLimit = 20
+)
+
+// SimilarImage is an image found by perceptual hash, with the number of bits its hash differs by
+type SimilarImage struct {
+	Image
+	Distance int `json:"distance"`
+}
+
+// ParsePerceptualHash reads a perceptual hash written by FormatPerceptualHash
+func ParsePerceptualHash(hash string) (uint64, error) {
+	value, err := strconv.ParseUint(hash, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid perceptual hash %q: %w", hash, err)
+	}
+	return value, nil
+}
+
+// FormatPerceptualHash writes a 64 bit perceptual hash as 16 hex digits
+func FormatPerceptualHash(hash uint64) string {
+	return fmt.Sprintf("%016x", hash)
+}
+
+// HammingDistance counts the bits two perceptual hashes differ by
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
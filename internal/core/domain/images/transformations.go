@@ -2,6 +2,7 @@ package images
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -12,9 +13,18 @@ type TransformationRequest struct {
 }
 
 // ResizeConfig holds configuration for resizing transformation.
+// When only one of Width and Height is given the other follows the aspect ratio.
 type ResizeConfig struct {
-	Width  int `json:"width" validate:"required,gte=1"`
-	Height int `json:"height" validate:"required,gte=1"`
+	Width  int `json:"width" validate:"required_without=Height,omitempty,gte=1"`
+	Height int `json:"height" validate:"required_without=Width,omitempty,gte=1"`
+	// Mode decides how the image is fitted into Width x Height, cover when left out.
+	// contain is an alias of fit and crop an alias of cover.
+	Mode string `json:"mode" validate:"omitempty,oneof=fit contain fill cover crop pad"`
+	// Background fills the padding added by the pad mode, as a hex colour
+	Background         string `json:"background" validate:"omitempty,hexcolor"`
+	WithoutEnlargement bool   `json:"without_enlargement" mapstructure:"without_enlargement"`
+	// Kernel used for resampling, lanczos3 when left out
+	Kernel string `json:"kernel" validate:"omitempty,oneof=nearest linear cubic mitchell lanczos2 lanczos3"`
 }
 
 // GrayscaleConfig holds configuration for grayscale transformation.
@@ -24,9 +34,13 @@ type GrayscaleConfig struct {
 }
 
 // TrimConfig holds configuration for trim/crop transformation.
+// Borders are matched against Background, white when left out, or against the top-left pixel
+// when it is "auto". With Alpha the transparent border is trimmed instead and Background is ignored.
 type TrimConfig struct {
 	// Threshold for edge detection (0-255)
-	Threshold float64 `json:"threshold" validate:"gte=0,lte=255"`
+	Threshold  float64 `json:"threshold" validate:"gte=0,lte=255"`
+	Background string  `json:"background" validate:"omitempty,hexcolor|eq=auto"`
+	Alpha      bool    `json:"alpha"`
 }
 
 // BlurConfig holds configuration for blur transformation.
@@ -34,9 +48,214 @@ type BlurConfig struct {
 	Sigma float64 `json:"sigma" validate:"required,gt=0"`
 }
 
+// SharpenConfig holds configuration for sharpen transformation, with the parameters of vips sharpen.
+// Parameters left out take the vips defaults.
+type SharpenConfig struct {
+	// Sigma of the gaussian the sharpening mask is built from, 0.5 by default
+	Sigma float64 `json:"sigma" validate:"omitempty,gt=0,lte=10"`
+	// X1 is the threshold between flat and jaggy areas, 2 by default
+	X1 float64 `json:"x1" validate:"omitempty,gt=0,lte=100"`
+	// M2 is how strongly jaggy areas are sharpened, 3 by default
+	M2 float64 `json:"m2" validate:"omitempty,gt=0,lte=100"`
+}
+
+// MaxConvolveKernelSize is the largest width or height a convolution kernel may have.
+// Every kernel cell costs a pass over the image, so the limit keeps convolve cheap.
+const MaxConvolveKernelSize = 7
+
+// ConvolveConfig holds configuration for convolving the image with a custom kernel.
+// Kernel rows must all have the same length and both dimensions must be odd, so the kernel has a centre.
+type ConvolveConfig struct {
+	Kernel [][]float64 `json:"kernel" validate:"required,min=1,max=7,dive,min=1,max=7,dive,gte=-1000,lte=1000"`
+	// Scale the weighted sum is divided by, the sum of the kernel (or 1 when that is 0) when left out
+	Scale  float64 `json:"scale" validate:"gte=-100000,lte=100000"`
+	Offset float64 `json:"offset" validate:"gte=-65535,lte=65535"`
+}
+
 // RotateConfig holds configuration for rotation transformation.
+// Multiples of 90 are rotated losslessly, other angles enlarge the canvas and fill the corners with Background.
 type RotateConfig struct {
-	Angle int `json:"angle" validate:"required,oneof=90 180 270"`
+	// Angle in degrees, clockwise
+	Angle      float64 `json:"angle" validate:"required,gt=-360,lt=360"`
+	Background string  `json:"background" validate:"omitempty,hexcolor"`
+}
+
+// FlipConfig holds configuration for the flip (vertical) and flop (horizontal) mirroring transformations.
+type FlipConfig struct {
+	// No configuration needed for mirroring
+}
+
+// AutoOrientConfig holds configuration for the auto_orient transformation,
+// which applies the EXIF orientation to the pixels and then clears it.
+type AutoOrientConfig struct {
+	// No configuration needed for auto orientation
+}
+
+// CropConfig holds configuration for the crop transformation.
+// Without Gravity the Left/Top/Width/Height area is extracted and must fit inside the image.
+// With Gravity the Width x Height area is anchored to that side or picked by a smart strategy,
+// shrinking to the image size when it is larger.
+type CropConfig struct {
+	Left    int    `json:"left" validate:"gte=0"`
+	Top     int    `json:"top" validate:"gte=0"`
+	Width   int    `json:"width" validate:"required,gte=1"`
+	Height  int    `json:"height" validate:"required,gte=1"`
+	Gravity string `json:"gravity" validate:"omitempty,oneof=north north-east east south-east south south-west west north-west centre center attention entropy"`
+}
+
+// WatermarkKeyPrefix is the part of the bucket watermarks can be loaded from by StorageKey,
+// so a request can't composite the originals of other images into its output
+const WatermarkKeyPrefix = "watermarks/"
+
+// WatermarkConfig holds configuration for compositing another stored image onto the image.
+// The overlay is referenced by ImageID, using its transformed copy when there is one, or by StorageKey,
+// which has to be under WatermarkKeyPrefix.
+// With Gravity the overlay is anchored to that side, Margin pixels in from the edges,
+// without it the overlay is placed at Left/Top.
+type WatermarkConfig struct {
+	ImageID    string `json:"image_id" mapstructure:"image_id" validate:"required_without=StorageKey,omitempty,uuid"`
+	StorageKey string `json:"storage_key" mapstructure:"storage_key" validate:"required_without=ImageID,omitempty,startswith=watermarks/,excludes=.."`
+	Gravity    string `json:"gravity" validate:"omitempty,oneof=north north-east east south-east south south-west west north-west centre center"`
+	Margin     int    `json:"margin" validate:"gte=0"`
+	Left       int    `json:"left"`
+	Top        int    `json:"top"`
+	// Opacity from 0 to 1, fully opaque when left out
+	Opacity float64 `json:"opacity" validate:"omitempty,gt=0,lte=1"`
+	// Scale sets the overlay width relative to the image width, the overlay keeps its size when left out
+	Scale float64 `json:"scale" validate:"omitempty,gt=0,lte=1"`
+	// Tile repeats the overlay over the whole image, starting at its placement
+	Tile  bool   `json:"tile"`
+	Blend string `json:"blend" validate:"omitempty,oneof=over multiply screen overlay darken lighten colour-dodge colour-burn hard-light soft-light difference exclusion add"`
+}
+
+// TextConfig holds configuration for drawing a caption onto the image.
+// Text is rendered with the fonts bundled with the worker, so FontSize is in pixels,
+// and lines wrap at MaxWidth, the image width less margins and padding when left out.
+// With Gravity the caption is anchored to that side, Margin pixels in from the edges,
+// without it the caption is placed at Left/Top.
+type TextConfig struct {
+	Text       string `json:"text" validate:"required,max=1000"`
+	FontFamily string `json:"font_family" mapstructure:"font_family" validate:"omitempty,oneof=sans serif mono"`
+	FontSize   int    `json:"font_size" mapstructure:"font_size" validate:"omitempty,gte=1,lte=1000"`
+	FontWeight string `json:"font_weight" mapstructure:"font_weight" validate:"omitempty,oneof=normal bold"`
+	Color      string `json:"color" validate:"omitempty,hexcolor"`
+	Align      string `json:"align" validate:"omitempty,oneof=left centre center right"`
+	MaxWidth   int    `json:"max_width" mapstructure:"max_width" validate:"omitempty,gte=1,lte=16384"`
+	Gravity    string `json:"gravity" validate:"omitempty,oneof=north north-east east south-east south south-west west north-west centre center"`
+	Margin     int    `json:"margin" validate:"gte=0,lte=16384"`
+	Left       int    `json:"left"`
+	Top        int    `json:"top"`
+	// Background fills a box behind the caption, the caption is drawn straight onto the image when left out
+	Background string `json:"background" validate:"omitempty,hexcolor"`
+	Padding    int    `json:"padding" validate:"gte=0,lte=16384"`
+}
+
+// ModulateConfig holds configuration for adjusting the colours of the image.
+// Brightness, contrast and saturation are multipliers where 1 leaves the image unchanged,
+// as does leaving them out. Hue rotates every colour by that many degrees.
+type ModulateConfig struct {
+	Brightness float64 `json:"brightness" validate:"omitempty,gt=0,lte=10"`
+	Contrast   float64 `json:"contrast" validate:"omitempty,gt=0,lte=10"`
+	Saturation float64 `json:"saturation" validate:"omitempty,gt=0,lte=10"`
+	Hue        float64 `json:"hue" validate:"gt=-360,lt=360"`
+	// Gamma is the exponent the image is corrected with, values above 1 lighten the midtones
+	Gamma float64 `json:"gamma" validate:"omitempty,gte=0.1,lte=10"`
+}
+
+// SepiaConfig holds configuration for the sepia transformation.
+type SepiaConfig struct {
+	// Intensity from 0 to 1 blends between the original colours and full sepia, the default
+	Intensity float64 `json:"intensity" validate:"omitempty,gt=0,lte=1"`
+}
+
+// TintConfig holds configuration for the tint transformation,
+// which maps the luminance of the image onto a ramp from black to Color.
+type TintConfig struct {
+	Color string `json:"color" validate:"required,hexcolor"`
+}
+
+// NegateConfig holds configuration for the negate transformation.
+type NegateConfig struct {
+	// Alpha negates the alpha channel too, only the colours are negated by default
+	Alpha bool `json:"alpha"`
+}
+
+// MaxCanvasSize is the largest width or height an extended canvas or a rendered caption may have
+const MaxCanvasSize = 16384
+
+// ExtendConfig holds configuration for adding borders around the image.
+// Either pad each side by Top/Right/Bottom/Left pixels, or give a Width x Height canvas the image
+// is placed on by Gravity, centred by default. A canvas smaller than the image keeps the image size.
+// The new area is filled with Background in solid mode, or with the image edges in the others.
+type ExtendConfig struct {
+	Top        int    `json:"top" validate:"gte=0,lte=16384"`
+	Right      int    `json:"right" validate:"gte=0,lte=16384"`
+	Bottom     int    `json:"bottom" validate:"gte=0,lte=16384"`
+	Left       int    `json:"left" validate:"gte=0,lte=16384"`
+	Width      int    `json:"width" validate:"omitempty,gte=1,lte=16384,excluded_with=Top Right Bottom Left"`
+	Height     int    `json:"height" validate:"omitempty,gte=1,lte=16384,excluded_with=Top Right Bottom Left"`
+	Gravity    string `json:"gravity" validate:"omitempty,oneof=north north-east east south-east south south-west west north-west centre center"`
+	Mode       string `json:"mode" validate:"omitempty,oneof=solid copy mirror repeat"`
+	Background string `json:"background" validate:"omitempty,hexcolor"`
+}
+
+// MaxLoadDPI is the highest density PDF and SVG images can be rasterized at
+const MaxLoadDPI = 1200
+
+// MaxLoadPages is the most pages a page range can select, every page is processed and stored on its own
+const MaxLoadPages = 100
+
+// LoadConfig holds configuration for how the image is decoded, which is why the load step has to come first.
+// Page picks one page of a PDF, TIFF or animation, counting from 1. PageRange such as "2-5" picks several,
+// and each page is processed and stored as an output of its own; a range past the last page stops there.
+// DPI is the density PDF and SVG images are rasterized at, 72 when left out, and Width instead picks
+// the density that rasterizes them that many pixels wide. Other formats ignore DPI and Width.
+type LoadConfig struct {
+	Page      int    `json:"page" validate:"omitempty,gte=1,excluded_with=PageRange"`
+	PageRange string `json:"page_range" mapstructure:"page_range" validate:"omitempty,max=32"`
+	DPI       int    `json:"dpi" validate:"omitempty,gte=1,lte=1200,excluded_with=Width"`
+	Width     int    `json:"width" validate:"omitempty,gte=1,lte=16384"`
+}
+
+// ParsePageRange parses a range of pages such as "2-5", or a single page such as "3",
+// into its first page, counting from 1, and the number of pages it covers
+func ParsePageRange(pageRange string) (int, int, error) {
+	firstPart, lastPart, isRange := strings.Cut(strings.TrimSpace(pageRange), "-")
+	if !isRange {
+		lastPart = firstPart
+	}
+
+	first, err := strconv.Atoi(strings.TrimSpace(firstPart))
+	if err != nil || first < 1 {
+		return 0, 0, fmt.Errorf("page range %q must start at a page number of at least 1", pageRange)
+	}
+	last, err := strconv.Atoi(strings.TrimSpace(lastPart))
+	if err != nil || last < first {
+		return 0, 0, fmt.Errorf("page range %q must end at a page number no lower than its start", pageRange)
+	}
+
+	count := last - first + 1
+	if count > MaxLoadPages {
+		return 0, 0, fmt.Errorf("page range %q covers %d pages, at most %d are allowed", pageRange, count, MaxLoadPages)
+	}
+
+	return first, count, nil
+}
+
+// FrameConfig holds configuration for turning an animated GIF or WebP into a still of one of its frames.
+// Left out, Index picks the first frame, the poster of the animation. A still image only has frame 0.
+type FrameConfig struct {
+	Index int `json:"index" validate:"gte=0"`
+}
+
+// FormatConfig holds configuration for the output format transformation.
+type FormatConfig struct {
+	Format string `json:"format" validate:"required,oneof=jpeg png webp avif gif tiff"`
+	// Quality from 1 to 100, the encoder default is used when left out
+	Quality       int  `json:"quality" validate:"omitempty,gte=1,lte=100"`
+	Lossless      bool `json:"lossless"`
+	StripMetadata bool `json:"strip_metadata" mapstructure:"strip_metadata"`
+	Interlace     bool `json:"interlace"`
 }
 
 // ParseTransformations parses a slice of transformation requests.
@@ -0,0 +1,120 @@
+package images
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func TestWatermarkConfigStorageKey(t *testing.T) {
+	validate := validator.New()
+
+	tests := []struct {
+		name    string
+		cfg     WatermarkConfig
+		wantErr bool
+	}{
+		{name: "watermark key", cfg: WatermarkConfig{StorageKey: "watermarks/logo.png"}},
+		{name: "nested watermark key", cfg: WatermarkConfig{StorageKey: "watermarks/brand/logo.png"}},
+		{name: "image id", cfg: WatermarkConfig{ImageID: "5b0f1c2e-8a43-4a57-9b8e-2f1e6d3c4b5a"}},
+		{name: "neither", cfg: WatermarkConfig{}, wantErr: true},
+		{name: "raw original", cfg: WatermarkConfig{StorageKey: "raw-images/5b0f1c2e-8a43-4a57-9b8e-2f1e6d3c4b5a.png"}, wantErr: true},
+		{name: "escapes prefix", cfg: WatermarkConfig{StorageKey: "watermarks/../raw-images/a.png"}, wantErr: true},
+		{name: "prefix without slash", cfg: WatermarkConfig{StorageKey: "watermarks-private/logo.png"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.Struct(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate.Struct() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTextConfigMaxWidth(t *testing.T) {
+	validate := validator.New()
+
+	tests := []struct {
+		name     string
+		maxWidth int
+		wantErr  bool
+	}{
+		{name: "image width", maxWidth: 0},
+		{name: "one pixel", maxWidth: 1},
+		{name: "largest canvas", maxWidth: MaxCanvasSize},
+		{name: "over largest canvas", maxWidth: MaxCanvasSize + 1, wantErr: true},
+		{name: "negative", maxWidth: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.Struct(TextConfig{Text: "caption", MaxWidth: tt.maxWidth})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate.Struct() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTextConfigMarginAndPadding(t *testing.T) {
+	validate := validator.New()
+
+	tests := []struct {
+		name    string
+		margin  int
+		padding int
+		wantErr bool
+	}{
+		{name: "none"},
+		{name: "largest canvas", margin: MaxCanvasSize, padding: MaxCanvasSize},
+		{name: "margin over largest canvas", margin: MaxCanvasSize + 1, wantErr: true},
+		{name: "padding over largest canvas", padding: MaxCanvasSize + 1, wantErr: true},
+		{name: "padding that overflows", padding: 1 << 62, wantErr: true},
+		{name: "negative margin", margin: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.Struct(TextConfig{Text: "caption", Margin: tt.margin, Padding: tt.padding})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate.Struct() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParsePageRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		pageRange string
+		wantFirst int
+		wantCount int
+		wantErr   bool
+	}{
+		{name: "single page", pageRange: "3", wantFirst: 3, wantCount: 1},
+		{name: "range", pageRange: "2-5", wantFirst: 2, wantCount: 4},
+		{name: "spaces", pageRange: " 2 - 5 ", wantFirst: 2, wantCount: 4},
+		{name: "one page range", pageRange: "4-4", wantFirst: 4, wantCount: 1},
+		{name: "most pages", pageRange: "1-100", wantFirst: 1, wantCount: MaxLoadPages},
+		{name: "reversed", pageRange: "5-2", wantErr: true},
+		{name: "page zero", pageRange: "0-3", wantErr: true},
+		{name: "over the page limit", pageRange: "1-101", wantErr: true},
+		{name: "not a number", pageRange: "abc", wantErr: true},
+		{name: "open end", pageRange: "2-", wantErr: true},
+		{name: "empty", pageRange: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, count, err := ParsePageRange(tt.pageRange)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePageRange(%q) error = %v, wantErr %v", tt.pageRange, err, tt.wantErr)
+			}
+			if first != tt.wantFirst || count != tt.wantCount {
+				t.Errorf("ParsePageRange(%q) = %d, %d, want %d, %d", tt.pageRange, first, count, tt.wantFirst, tt.wantCount)
+			}
+		})
+	}
+}
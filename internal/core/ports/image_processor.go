@@ -27,10 +27,58 @@ type ImageTransformerFactory interface {
 	CreateTransformer(ctx context.Context, req images.TransformationRequest) (ImageTransformer, error)
 }
 
+// TransformerRegistration describes a transformer to an ImageTransformerRegistry
+type TransformerRegistration struct {
+	Transformer ImageTransformer
+
+	// Aliases are other names the transformer can be requested by
+	Aliases []string
+
+	// RenderArgs are the config keys the arguments of render ops given without a key fill in, in order.
+	// images.RenderArgSize reads a WxH size into width and height.
+	RenderArgs []string
+
+	// AliasRenderArgs replace RenderArgs for aliases whose arguments mean something else, such as the dpi of density:300
+	AliasRenderArgs map[string][]string
+
+	// Description says what the transformation does, for people browsing the catalogue
+	Description string
+
+	// ConfigSchema is the JSON Schema of the config the transformer accepts, with its defaults
+	ConfigSchema map[string]any
+
+	// Examples are configs the transformer accepts
+	Examples []map[string]any
+}
+
+// ImageTransformerRegistry is a factory that transformers are registered with by name,
+// so the set of transformations can be extended and listed at runtime
+type ImageTransformerRegistry interface {
+	ImageTransformerFactory
+
+	// Register adds a transformer, failing when its name or an alias is already taken
+	Register(registration TransformerRegistration) error
+
+	// Registrations lists the registered transformers in the order they were registered
+	Registrations() []TransformerRegistration
+}
+
 // ImagePipelineProcessor processes images through a pipeline of transformations
 type ImagePipelineProcessor interface {
-	ProcessPipeline(ctx context.Context, image io.Reader, transformations []images.TransformationRequest) ([]byte, error)
+	ProcessPipeline(ctx context.Context, image io.Reader, transformations []images.TransformationRequest) (*images.ProcessedImage, error)
 	ValidateTransformations(ctx context.Context, transformations []images.TransformationRequest) error
 }
 
+// ImageInspector reads the properties of an image without running it through a pipeline
+type ImageInspector interface {
+	Inspect(ctx context.Context, image []byte) (*images.ImageProperties, error)
+
+	// PerceptualHash returns a 64 bit hash, formatted by images.FormatPerceptualHash,
+	// that differs by few bits between images that look alike
+	PerceptualHash(ctx context.Context, image []byte) (string, error)
+
+	// Placeholder returns a BlurHash, the dominant colour and a palette of the image
+	Placeholder(ctx context.Context, image []byte) (*images.ImagePlaceholder, error)
+}
+
 var ErrUnknownImageTransformer = errors.New("unknown image transformer")
@@ -3,6 +3,7 @@ package ports
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/taldoflemis/sora-henkan/internal/core/domain/images"
 )
@@ -10,9 +11,33 @@ import (
 type ImageRepository interface {
 	CreateNewImage(ctx context.Context, image *images.Image) error
 	UpdateImage(ctx context.Context, image *images.Image) error
+
+	// UpdateImageFromStatus updates an image only while it still has the given status, so only one of
+	// several concurrent transitions out of a status succeeds. The others get ErrImageStatusChanged.
+	UpdateImageFromStatus(ctx context.Context, image *images.Image, status string) error
+
 	DeleteImage(ctx context.Context, id string) error
 	FindImageByID(ctx context.Context, id string) (*images.Image, error)
 	FindAllImages(ctx context.Context, req *images.ListImagesRequest) (*images.ListImagesResponse, error)
+
+	// FindExpiredUploads lists up to limit images still awaiting an upload that were reserved before reservedBefore, oldest first
+	FindExpiredUploads(ctx context.Context, reservedBefore time.Time, limit int) ([]images.Image, error)
+
+	// FindImagesByChecksum finds the images whose original has the given SHA-256 hex digest or CRC32C checksum
+	FindImagesByChecksum(ctx context.Context, checksum string) ([]images.Image, error)
+
+	// FindSimilarImages finds the images whose perceptual hash is at most maxDistance bits away
+	// from the one of image, closest first
+	FindSimilarImages(ctx context.Context, image *images.Image, maxDistance int, limit int) ([]images.SimilarImage, error)
+
+	// AcquireOriginal makes the image reference the stored original with the given SHA-256,
+	// registering key as its object when none is stored yet, and returns the key it is stored under.
+	// An image references an original once, however often it is acquired.
+	AcquireOriginal(ctx context.Context, sha256 string, imageID string, key string) (string, error)
+
+	// ReleaseOriginal drops the reference of the image to the stored original with the given SHA-256 and
+	// returns its key once the last reference is gone, or an empty key while other images still use it
+	ReleaseOriginal(ctx context.Context, sha256 string, imageID string) (string, error)
 }
 
 // ImageMetadataRepository is responsible for storing image metadata for fast querying
@@ -25,4 +50,5 @@ type ImageMetadataRepository interface {
 }
 
 var ErrImageNotFound = errors.New("image not found")
+var ErrImageStatusChanged = errors.New("image status changed")
 var ErrMetadataNotFound = errors.New("metadata not found")
@@ -2,13 +2,32 @@ package ports
 
 import (
 	"context"
+	"errors"
 	"io"
+	"time"
 )
 
+// ObjectInfo describes a stored object without its content
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+	// ChecksumCRC32C is the base64 CRC32C the uploader sent along with the object, if any
+	ChecksumCRC32C string
+}
+
 type ObjectStorer interface {
 	Store(ctx context.Context, key string, bucket string, mimeType string, obj io.Reader) error
-	Get(ctx context.Context, key string, bucket string) (io.ReadCloser, error)
+	Get(ctx context.Context, key string, bucket string) (io.ReadSeekCloser, error)
+	GetRange(ctx context.Context, key string, bucket string, offset int64, length int64) (io.ReadCloser, error)
+	Stat(ctx context.Context, key string, bucket string) (*ObjectInfo, error)
 	Delete(ctx context.Context, key string, bucket string) error
+	DeletePrefix(ctx context.Context, prefix string, bucket string) error
 	ListKeys(ctx context.Context) ([]string, error)
-	PresignedUpload(ctx context.Context, bucket string) (string, error)
+	PresignedUpload(ctx context.Context, key string, bucket string, expiry time.Duration) (string, error)
+	PresignedDownload(ctx context.Context, key string, bucket string, expiry time.Duration) (string, error)
 }
+
+var ErrObjectNotFound = errors.New("object not found")
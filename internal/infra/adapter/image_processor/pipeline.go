@@ -5,62 +5,22 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
-	"strings"
 
+	"github.com/davidbyttow/govips/v2/vips"
 	"github.com/taldoflemis/sora-henkan/internal/core/domain/images"
 	"github.com/taldoflemis/sora-henkan/internal/core/ports"
 )
 
-// TransformerFactory implements the ImageTransformerFactory interface with injected transformers
-type TransformerFactory struct {
-	resizeTransformer    ports.ImageTransformer
-	grayscaleTransformer ports.ImageTransformer
-	trimTransformer      ports.ImageTransformer
-	blurTransformer      ports.ImageTransformer
-	rotateTransformer    ports.ImageTransformer
-}
-
-var _ ports.ImageTransformerFactory = (*TransformerFactory)(nil)
-
-func NewTransformerFactory(
-	resizeTransformer ports.ImageTransformer,
-	grayscaleTransformer ports.ImageTransformer,
-	trimTransformer ports.ImageTransformer,
-	blurTransformer ports.ImageTransformer,
-	rotateTransformer ports.ImageTransformer,
-) *TransformerFactory {
-	return &TransformerFactory{
-		resizeTransformer:    resizeTransformer,
-		grayscaleTransformer: grayscaleTransformer,
-		trimTransformer:      trimTransformer,
-		blurTransformer:      blurTransformer,
-		rotateTransformer:    rotateTransformer,
-	}
-}
-
-// CreateTransformer creates a transformer from a transformation request
-func (f *TransformerFactory) CreateTransformer(ctx context.Context, req images.TransformationRequest) (ports.ImageTransformer, error) {
-	transformName := strings.ToLower(strings.TrimSpace(req.Name))
-
-	switch transformName {
-	case "resize":
-		return f.resizeTransformer, nil
-
-	case "grayscale":
-		return f.grayscaleTransformer, nil
-
-	case "trim":
-		return f.trimTransformer, nil
-
-	case "blur":
-		return f.blurTransformer, nil
-
-	case "rotate":
-		return f.rotateTransformer, nil
-
-	default:
-		return nil, fmt.Errorf("unknown transformation: %s", req.Name)
-	}
+// outputMimeTypes maps the extensions of the formats a pipeline can end in to their MIME types
+var outputMimeTypes = map[string]string{
+	".avif": "image/avif",
+	".bmp":  "image/bmp",
+	".gif":  "image/gif",
+	".heic": "image/heif",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+	".tiff": "image/tiff",
+	".webp": "image/webp",
 }
 
 // Pipeline implements the ImagePipelineProcessor interface with injected transformers
@@ -95,6 +55,10 @@ func (p *Pipeline) ValidateTransformations(ctx context.Context, transformations
 			return fmt.Errorf("invalid transformation at step %d (%s): %w", i+1, txReq.Name, err)
 		}
 
+		if err := checkStepPosition(transformer, i); err != nil {
+			return fmt.Errorf("invalid transformation at step %d (%s): %w", i+1, txReq.Name, err)
+		}
+
 		configMap := txReq.Config
 		if configMap == nil {
 			configMap = make(map[string]any)
@@ -118,8 +82,9 @@ func (p *Pipeline) ValidateTransformations(ctx context.Context, transformations
 	return nil
 }
 
-// ProcessPipeline processes an image through a pipeline of transformations
-func (p *Pipeline) ProcessPipeline(ctx context.Context, image io.Reader, transformations []images.TransformationRequest) ([]byte, error) {
+// ProcessPipeline processes an image through a pipeline of transformations.
+// The image is decoded once, every step works on the same decoded image and it is encoded once at the end.
+func (p *Pipeline) ProcessPipeline(ctx context.Context, image io.Reader, transformations []images.TransformationRequest) (*images.ProcessedImage, error) {
 	slog.InfoContext(ctx, "Starting image transformation pipeline", slog.Int("steps", len(transformations)))
 
 	// Read initial image data
@@ -129,10 +94,8 @@ func (p *Pipeline) ProcessPipeline(ctx context.Context, image io.Reader, transfo
 		return nil, fmt.Errorf("failed to read initial image data: %w", err)
 	}
 
-	// Current image data
-	currentData := initialData
-
-	// Build and execute the pipeline
+	// Build the whole pipeline first, so a bad step fails before any image work is done
+	transformers := make([]ports.ImageTransformer, 0, len(transformations))
 	for i, txReq := range transformations {
 		slog.DebugContext(ctx, "Building transformation step",
 			slog.Int("step", i+1),
@@ -148,6 +111,10 @@ func (p *Pipeline) ProcessPipeline(ctx context.Context, image io.Reader, transfo
 			return nil, fmt.Errorf("failed to create transformer at step %d (%s): %w", i+1, txReq.Name, err)
 		}
 
+		if err := checkStepPosition(transformer, i); err != nil {
+			return nil, fmt.Errorf("invalid transformation at step %d (%s): %w", i+1, txReq.Name, err)
+		}
+
 		// Validate config before transformation
 		if err := transformer.ValidateConfig(ctx, txReq.Config); err != nil {
 			slog.ErrorContext(ctx, "Config validation failed",
@@ -157,12 +124,44 @@ func (p *Pipeline) ProcessPipeline(ctx context.Context, image io.Reader, transfo
 			return nil, fmt.Errorf("config validation failed at step %d (%s): %w", i+1, transformer.Name(), err)
 		}
 
+		transformers = append(transformers, transformer)
+	}
+
+	// A load step decides how the image is decoded, so it runs before the image exists
+	loadOptions := defaultLoadOptions(initialData)
+	if len(transformers) > 0 {
+		if loader, ok := transformers[0].(VipsImageLoader); ok {
+			loadOptions, err = loader.LoadOptions(ctx, initialData, transformations[0].Config)
+			if err != nil {
+				slog.ErrorContext(ctx, "Failed to pick load options", slog.Any("err", err))
+				return nil, fmt.Errorf("transformation failed at step 1 (%s): %w", loader.Name(), err)
+			}
+		}
+	}
+
+	vipsImage, err := loadVipsImage(initialData, loadOptions)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to decode image", slog.Any("err", err))
+		return nil, err
+	}
+	// Close reads Ref when it runs, so a step that swaps the image still gets the new one released
+	defer vipsImage.Close()
+
+	// Execute the pipeline
+	for i, transformer := range transformers {
+		txReq := transformations[i]
+
 		// Apply the transformation
 		slog.InfoContext(ctx, "Applying transformation",
 			slog.Int("step", i+1),
 			slog.String("transformation", transformer.Name()))
 
-		transformedData, err := transformer.Transform(ctx, currentData, txReq.Config)
+		if vipsTransformer, ok := transformer.(VipsImageTransformer); ok {
+			err = applyTransformer(ctx, vipsTransformer, vipsImage, txReq.Config)
+		} else {
+			// Transformers without Apply only know about bytes, so they pay for a round trip
+			err = p.transformEncoded(ctx, transformer, vipsImage, txReq.Config)
+		}
 		if err != nil {
 			slog.ErrorContext(ctx, "Transformation failed",
 				slog.Int("step", i+1),
@@ -171,18 +170,80 @@ func (p *Pipeline) ProcessPipeline(ctx context.Context, image io.Reader, transfo
 			return nil, fmt.Errorf("transformation failed at step %d (%s): %w", i+1, transformer.Name(), err)
 		}
 
-		// Update current data for next step
-		currentData = transformedData
-
 		slog.DebugContext(ctx, "Transformation completed successfully",
 			slog.Int("step", i+1),
 			slog.String("transformation", transformer.Name()),
-			slog.Int("output_size", len(transformedData)))
+			slog.Int("width", vipsImage.Ref.Width()),
+			slog.Int("height", vipsImage.Ref.PageHeight()),
+			slog.Int("frames", vipsImage.Frames()))
+	}
+
+	pages, err := vipsImage.ExportPages()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to encode pages", slog.Any("err", err))
+		return nil, fmt.Errorf("failed to encode pages: %w", err)
+	}
+
+	var currentData []byte
+	if pages != nil {
+		currentData = pages[0]
+	} else {
+		currentData, err = vipsImage.Export()
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to encode image", slog.Any("err", err))
+			return nil, fmt.Errorf("failed to encode image: %w", err)
+		}
 	}
 
 	slog.InfoContext(ctx, "Image transformation pipeline completed successfully",
 		slog.Int("total_steps", len(transformations)),
-		slog.Int("final_size", len(currentData)))
+		slog.Int("final_size", len(currentData)),
+		slog.Int("pages", len(pages)))
+
+	// A format step may have changed the encoding, so the output is sniffed instead of assumed
+	imageType := vips.DetermineImageType(currentData)
+	mimeType, ok := outputMimeTypes[imageType.FileExt()]
+	if !ok {
+		slog.ErrorContext(ctx, "Unsupported output format", slog.Int("image_type", int(imageType)))
+		return nil, fmt.Errorf("unsupported output format: %s", vips.ImageTypes[imageType])
+	}
 
-	return currentData, nil
+	return &images.ProcessedImage{
+		Data:      currentData,
+		MimeType:  mimeType,
+		Extension: imageType.FileExt(),
+		Pages:     pages,
+	}, nil
+}
+
+// checkStepPosition rejects load steps anywhere but first, as the image is already decoded by then
+func checkStepPosition(transformer ports.ImageTransformer, step int) error {
+	if _, ok := transformer.(VipsImageLoader); ok && step > 0 {
+		return fmt.Errorf("%s has to be the first step", transformer.Name())
+	}
+	return nil
+}
+
+// transformEncoded runs a byte based transformer on the shared image by encoding it,
+// transforming the bytes and decoding the result back into the shared image
+func (p *Pipeline) transformEncoded(ctx context.Context, transformer ports.ImageTransformer, image *VipsImage, config map[string]any) error {
+	encoded, _, err := image.Ref.ExportNative()
+	if err != nil {
+		return fmt.Errorf("failed to export image: %w", err)
+	}
+
+	transformed, err := transformer.Transform(ctx, encoded, config)
+	if err != nil {
+		return err
+	}
+
+	imageRef, err := vips.NewImageFromBuffer(transformed)
+	if err != nil {
+		return fmt.Errorf("failed to load image: %w", err)
+	}
+
+	image.Ref.Close()
+	image.Ref = imageRef
+
+	return nil
 }
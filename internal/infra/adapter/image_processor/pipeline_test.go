@@ -0,0 +1,81 @@
+package image
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+
+	"github.com/taldoflemis/sora-henkan/internal/core/domain/images"
+)
+
+// benchmarkSteps is a typical five step pipeline, each step with its own transformer
+var benchmarkSteps = []images.TransformationRequest{
+	{Name: "resize", Config: map[string]any{"width": 1280, "height": 720}},
+	{Name: "grayscale", Config: map[string]any{}},
+	{Name: "blur", Config: map[string]any{"sigma": 1.5}},
+	{Name: "rotate", Config: map[string]any{"angle": 90}},
+	{Name: "flip", Config: map[string]any{}},
+}
+
+// benchmarkFixture encodes a 1920x1080 gradient as a JPEG, about the size of a phone photo after resizing
+func benchmarkFixture(b *testing.B) []byte {
+	b.Helper()
+
+	fixture := image.NewRGBA(image.Rect(0, 0, 1920, 1080))
+	for y := range 1080 {
+		for x := range 1920 {
+			fixture.Set(x, y, color.RGBA{R: uint8(x * 255 / 1920), G: uint8(y * 255 / 1080), B: uint8((x + y) % 256), A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, fixture, &jpeg.Options{Quality: 90}); err != nil {
+		b.Fatalf("failed to encode fixture: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// BenchmarkProcessPipeline compares running the steps through Transform, which decodes and encodes
+// the image around every step, with ProcessPipeline, which decodes the image once, applies every step
+// to it and encodes it once at the end. Both look the transformers up in the same registry.
+func BenchmarkProcessPipeline(b *testing.B) {
+	ctx := context.Background()
+	fixture := benchmarkFixture(b)
+
+	registry, err := NewVipsTransformerRegistry(TransformerDependencies{})
+	if err != nil {
+		b.Fatalf("failed to create transformer registry: %v", err)
+	}
+
+	b.Run("transform round trips", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			data := fixture
+			for _, step := range benchmarkSteps {
+				transformer, err := registry.CreateTransformer(ctx, step)
+				if err != nil {
+					b.Fatalf("failed to create %s: %v", step.Name, err)
+				}
+
+				data, err = transformer.Transform(ctx, data, step.Config)
+				if err != nil {
+					b.Fatalf("%s failed: %v", step.Name, err)
+				}
+			}
+		}
+	})
+
+	b.Run("pipeline", func(b *testing.B) {
+		pipeline := NewPipeline(registry)
+
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := pipeline.ProcessPipeline(ctx, bytes.NewReader(fixture), benchmarkSteps); err != nil {
+				b.Fatalf("failed to process pipeline: %v", err)
+			}
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/taldoflemis/sora-henkan/internal/core/domain/images"
+	"github.com/taldoflemis/sora-henkan/internal/core/ports"
+)
+
+// TransformerRegistry implements the ImageTransformerRegistry interface, looking transformers up by name or alias
+type TransformerRegistry struct {
+	registrations []ports.TransformerRegistration
+	// byName indexes registrations by name and alias
+	byName map[string]int
+}
+
+var _ ports.ImageTransformerRegistry = (*TransformerRegistry)(nil)
+
+func NewTransformerRegistry() *TransformerRegistry {
+	return &TransformerRegistry{
+		byName: make(map[string]int),
+	}
+}
+
+// Register adds a transformer under its name and aliases
+func (r *TransformerRegistry) Register(registration ports.TransformerRegistration) error {
+	names := append([]string{registration.Transformer.Name()}, registration.Aliases...)
+
+	for _, name := range names {
+		if _, taken := r.byName[normalizeTransformerName(name)]; taken {
+			return fmt.Errorf("transformer name %q is already registered", name)
+		}
+	}
+
+	r.registrations = append(r.registrations, registration)
+	for _, name := range names {
+		r.byName[normalizeTransformerName(name)] = len(r.registrations) - 1
+	}
+
+	return nil
+}
+
+// Registrations lists the registered transformers in the order they were registered
+func (r *TransformerRegistry) Registrations() []ports.TransformerRegistration {
+	registrations := make([]ports.TransformerRegistration, len(r.registrations))
+	copy(registrations, r.registrations)
+	return registrations
+}
+
+// CreateTransformer finds the transformer registered under the request name or alias
+func (r *TransformerRegistry) CreateTransformer(ctx context.Context, req images.TransformationRequest) (ports.ImageTransformer, error) {
+	index, ok := r.byName[normalizeTransformerName(req.Name)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ports.ErrUnknownImageTransformer, req.Name)
+	}
+
+	return r.registrations[index].Transformer, nil
+}
+
+func normalizeTransformerName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// TransformerDependencies holds what the built-in transformers need from the rest of the application
+type TransformerDependencies struct {
+	ObjectStorer    ports.ObjectStorer
+	ImageRepository ports.ImageRepository
+	// BucketName is where watermark images are read from
+	BucketName string
+	// FontsDir holds the fonts text is rendered with
+	FontsDir string
+}
+
+// vipsTransformers creates the registrations of the built-in VIPS transformers,
+// each vips_*.go file adds the transformers it implements from init
+var vipsTransformers []func(deps TransformerDependencies) ports.TransformerRegistration
+
+// registerVipsTransformer adds a built-in transformer, register is called with the dependencies every time a registry is created
+func registerVipsTransformer(register func(deps TransformerDependencies) ports.TransformerRegistration) {
+	vipsTransformers = append(vipsTransformers, register)
+}
+
+// NewVipsTransformerRegistry creates a registry holding every built-in VIPS transformer, ordered by name.
+// A new transformer only needs to register itself to be usable and listed in the catalogue.
+func NewVipsTransformerRegistry(deps TransformerDependencies) (*TransformerRegistry, error) {
+	registrations := make([]ports.TransformerRegistration, 0, len(vipsTransformers))
+	for _, register := range vipsTransformers {
+		registrations = append(registrations, register(deps))
+	}
+
+	// init runs file by file, sorting keeps the order independent of which file a transformer lives in
+	slices.SortFunc(registrations, func(a, b ports.TransformerRegistration) int {
+		return strings.Compare(a.Transformer.Name(), b.Transformer.Name())
+	})
+
+	registry := NewTransformerRegistry()
+	for _, registration := range registrations {
+		if err := registry.Register(registration); err != nil {
+			return nil, err
+		}
+	}
+
+	return registry, nil
+}
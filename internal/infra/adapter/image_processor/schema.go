@@ -0,0 +1,160 @@
+package image
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// hexColorPattern matches the colours the hexcolor validation accepts
+const hexColorPattern = "^#([0-9a-fA-F]{3}|[0-9a-fA-F]{4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$"
+
+// configSchema builds the JSON Schema of a config struct from its json and validate tags,
+// so the schema a transformer publishes can't drift from what ValidateConfig accepts.
+// The fields set in defaults are published as the default of their property.
+// Rules without a JSON Schema equivalent, such as required_without, are left out.
+func configSchema(defaults any) map[string]any {
+	configType := reflect.TypeOf(defaults)
+	configValue := reflect.ValueOf(defaults)
+
+	properties := map[string]any{}
+	required := []string{}
+
+	for i := range configType.NumField() {
+		field := configType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		rules := strings.Split(field.Tag.Get("validate"), ",")
+		if len(rules) > 0 && rules[0] == "required" {
+			required = append(required, name)
+		}
+
+		property := fieldSchema(field.Type, rules)
+		if value := configValue.Field(i); !value.IsZero() {
+			property["default"] = value.Interface()
+		}
+		properties[name] = property
+	}
+
+	schema := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	return schema
+}
+
+// fieldSchema builds the schema of a value of fieldType checked by rules,
+// rules after a dive apply to the elements of a slice
+func fieldSchema(fieldType reflect.Type, rules []string) map[string]any {
+	schema := map[string]any{}
+
+	switch fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		schema["type"] = "integer"
+	case reflect.Float32, reflect.Float64:
+		schema["type"] = "number"
+	case reflect.Bool:
+		schema["type"] = "boolean"
+	case reflect.String:
+		schema["type"] = "string"
+	case reflect.Slice, reflect.Array:
+		schema["type"] = "array"
+	case reflect.Map, reflect.Struct:
+		schema["type"] = "object"
+	}
+
+	for i, rule := range rules {
+		if rule == "dive" {
+			if schema["type"] == "array" {
+				schema["items"] = fieldSchema(fieldType.Elem(), rules[i+1:])
+			}
+			break
+		}
+
+		// Alternatives such as hexcolor|eq=auto accept a value matching any of them
+		if strings.Contains(rule, "|") {
+			alternatives := []any{}
+			for _, alternative := range strings.Split(rule, "|") {
+				alternativeSchema := map[string]any{}
+				applyRule(alternativeSchema, fieldType.Kind(), alternative)
+				alternatives = append(alternatives, alternativeSchema)
+			}
+			schema["anyOf"] = alternatives
+			continue
+		}
+
+		applyRule(schema, fieldType.Kind(), rule)
+	}
+
+	return schema
+}
+
+// applyRule adds the keywords a single validate rule maps to, rules with no equivalent are skipped
+func applyRule(schema map[string]any, kind reflect.Kind, rule string) {
+	key, param, _ := strings.Cut(rule, "=")
+
+	switch key {
+	case "oneof":
+		values := []any{}
+		for _, value := range strings.Fields(param) {
+			values = append(values, value)
+		}
+		schema["enum"] = values
+	case "eq":
+		schema["const"] = param
+	case "hexcolor":
+		schema["pattern"] = hexColorPattern
+	case "uuid":
+		schema["format"] = "uuid"
+	case "gt", "gte", "lt", "lte", "min", "max":
+		bound, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return
+		}
+		schema[boundKeyword(kind, key)] = bound
+	}
+}
+
+// boundKeyword names the keyword a bound maps to, which for strings and slices limits their length
+func boundKeyword(kind reflect.Kind, rule string) string {
+	lower := rule == "gt" || rule == "gte" || rule == "min"
+
+	switch kind {
+	case reflect.String:
+		if lower {
+			return "minLength"
+		}
+		return "maxLength"
+	case reflect.Slice, reflect.Array:
+		if lower {
+			return "minItems"
+		}
+		return "maxItems"
+	}
+
+	switch rule {
+	case "gt":
+		return "exclusiveMinimum"
+	case "lt":
+		return "exclusiveMaximum"
+	case "gte", "min":
+		return "minimum"
+	default:
+		return "maximum"
+	}
+}
@@ -0,0 +1,416 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/davidbyttow/govips/v2/vips"
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/taldoflemis/sora-henkan/internal/core/domain/images"
+	"github.com/taldoflemis/sora-henkan/internal/core/ports"
+)
+
+func init() {
+	registerVipsTransformer(func(_ TransformerDependencies) ports.TransformerRegistration {
+		return ports.TransformerRegistration{
+			Transformer:  NewVipsModulateTransformer(),
+			Aliases:      []string{"adjust"},
+			RenderArgs:   []string{"brightness", "saturation", "hue"},
+			Description:  "Adjusts the brightness, contrast, saturation, hue and gamma of the image",
+			ConfigSchema: configSchema(defaultModulateConfig),
+			Examples: []map[string]any{
+				{"brightness": 1.2, "saturation": 0.8},
+				{"hue": 90},
+			},
+		}
+	})
+
+	registerVipsTransformer(func(_ TransformerDependencies) ports.TransformerRegistration {
+		return ports.TransformerRegistration{
+			Transformer:  NewVipsSepiaTransformer(),
+			RenderArgs:   []string{"intensity"},
+			Description:  "Gives the image a sepia tone",
+			ConfigSchema: configSchema(defaultSepiaConfig),
+			Examples:     []map[string]any{{"intensity": 0.6}},
+		}
+	})
+
+	registerVipsTransformer(func(_ TransformerDependencies) ports.TransformerRegistration {
+		return ports.TransformerRegistration{
+			Transformer:  NewVipsTintTransformer(),
+			RenderArgs:   []string{"color"},
+			Description:  "Maps the luminance of the image onto a ramp from black to a colour",
+			ConfigSchema: configSchema(images.TintConfig{}),
+			Examples:     []map[string]any{{"color": "#3366ff"}},
+		}
+	})
+
+	registerVipsTransformer(func(_ TransformerDependencies) ports.TransformerRegistration {
+		return ports.TransformerRegistration{
+			Transformer:  NewVipsNegateTransformer(),
+			Description:  "Inverts the colours of the image",
+			ConfigSchema: configSchema(images.NegateConfig{}),
+			Examples:     []map[string]any{{}, {"alpha": true}},
+		}
+	})
+}
+
+// sepiaMatrix recombines RGB into the classic sepia tone
+var sepiaMatrix = [3][3]float64{
+	{0.393, 0.769, 0.189},
+	{0.349, 0.686, 0.168},
+	{0.272, 0.534, 0.131},
+}
+
+// VipsModulateTransformer implements brightness, contrast, saturation, hue and gamma adjustments using VIPS
+type VipsModulateTransformer struct{}
+
+var _ VipsAnimationTransformer = (*VipsModulateTransformer)(nil)
+
+// defaultModulateConfig leaves the image unchanged, so only the adjustments asked for are made
+var defaultModulateConfig = images.ModulateConfig{
+	Brightness: 1,
+	Contrast:   1,
+	Saturation: 1,
+}
+
+func NewVipsModulateTransformer() *VipsModulateTransformer {
+	return &VipsModulateTransformer{}
+}
+
+func (t *VipsModulateTransformer) Name() string {
+	return "modulate"
+}
+
+// AppliesToAllFrames is safe as every pixel is adjusted on its own
+func (t *VipsModulateTransformer) AppliesToAllFrames() {}
+
+func (t *VipsModulateTransformer) ValidateConfig(ctx context.Context, config map[string]any) error {
+	var cfg images.ModulateConfig
+	if err := mapstructure.Decode(config, &cfg); err != nil {
+		return fmt.Errorf("failed to decode modulate config: %w", err)
+	}
+	if err := validate.Struct(cfg); err != nil {
+		return fmt.Errorf("invalid modulate config: %w", err)
+	}
+	return nil
+}
+
+func (t *VipsModulateTransformer) Transform(ctx context.Context, image []byte, config map[string]any) ([]byte, error) {
+	return transformBuffer(ctx, t, image, config)
+}
+
+func (t *VipsModulateTransformer) Apply(ctx context.Context, image *VipsImage, config map[string]any) error {
+	// Decode config, multipliers left out leave the image unchanged
+	cfg := defaultModulateConfig
+	if err := mapstructure.Decode(config, &cfg); err != nil {
+		return fmt.Errorf("failed to decode modulate config: %w", err)
+	}
+
+	slog.DebugContext(ctx, "Applying modulate transformation",
+		slog.Float64("brightness", cfg.Brightness),
+		slog.Float64("contrast", cfg.Contrast),
+		slog.Float64("saturation", cfg.Saturation),
+		slog.Float64("hue", cfg.Hue),
+		slog.Float64("gamma", cfg.Gamma))
+
+	imageRef := image.Ref
+
+	if cfg.Brightness != 1 || cfg.Contrast != 1 || cfg.Saturation != 1 || cfg.Hue != 0 {
+		colorspace := imageRef.ColorSpace()
+		if colorspace == vips.InterpretationRGB {
+			colorspace = vips.InterpretationSRGB
+		}
+
+		if err := imageRef.ToColorSpace(vips.InterpretationLCH); err != nil {
+			slog.ErrorContext(ctx, "Failed to convert to LCh", slog.Any("err", err))
+			return fmt.Errorf("failed to convert to LCh: %w", err)
+		}
+
+		// Lightness is scaled by brightness and then stretched around its midpoint of 50 by contrast,
+		// chroma is scaled by saturation and the hue angle is rotated. Other bands are left alone.
+		multipliers := make([]float64, imageRef.Bands())
+		offsets := make([]float64, imageRef.Bands())
+		for i := range multipliers {
+			multipliers[i] = 1
+		}
+		multipliers[0] = cfg.Brightness * cfg.Contrast
+		offsets[0] = 50 * (1 - cfg.Contrast)
+		multipliers[1] = cfg.Saturation
+		offsets[2] = cfg.Hue
+
+		if err := imageRef.Linear(multipliers, offsets); err != nil {
+			slog.ErrorContext(ctx, "Failed to modulate image", slog.Any("err", err))
+			return fmt.Errorf("failed to modulate image: %w", err)
+		}
+
+		if err := imageRef.ToColorSpace(colorspace); err != nil {
+			slog.ErrorContext(ctx, "Failed to convert from LCh", slog.Any("err", err))
+			return fmt.Errorf("failed to convert from LCh: %w", err)
+		}
+	}
+
+	if cfg.Gamma != 0 {
+		err := withoutAlpha(imageRef, func() error {
+			return imageRef.Gamma(cfg.Gamma)
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to adjust gamma", slog.Any("err", err))
+			return fmt.Errorf("failed to adjust gamma: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// VipsSepiaTransformer implements sepia toning using VIPS
+type VipsSepiaTransformer struct{}
+
+var _ VipsAnimationTransformer = (*VipsSepiaTransformer)(nil)
+
+// defaultSepiaConfig applies the full sepia tone
+var defaultSepiaConfig = images.SepiaConfig{
+	Intensity: 1,
+}
+
+func NewVipsSepiaTransformer() *VipsSepiaTransformer {
+	return &VipsSepiaTransformer{}
+}
+
+func (t *VipsSepiaTransformer) Name() string {
+	return "sepia"
+}
+
+// AppliesToAllFrames is safe as every pixel is adjusted on its own
+func (t *VipsSepiaTransformer) AppliesToAllFrames() {}
+
+func (t *VipsSepiaTransformer) ValidateConfig(ctx context.Context, config map[string]any) error {
+	var cfg images.SepiaConfig
+	if err := mapstructure.Decode(config, &cfg); err != nil {
+		return fmt.Errorf("failed to decode sepia config: %w", err)
+	}
+	if err := validate.Struct(cfg); err != nil {
+		return fmt.Errorf("invalid sepia config: %w", err)
+	}
+	return nil
+}
+
+func (t *VipsSepiaTransformer) Transform(ctx context.Context, image []byte, config map[string]any) ([]byte, error) {
+	return transformBuffer(ctx, t, image, config)
+}
+
+func (t *VipsSepiaTransformer) Apply(ctx context.Context, image *VipsImage, config map[string]any) error {
+	// Decode config with default value
+	cfg := defaultSepiaConfig
+	if err := mapstructure.Decode(config, &cfg); err != nil {
+		return fmt.Errorf("failed to decode sepia config: %w", err)
+	}
+
+	slog.DebugContext(ctx, "Applying sepia transformation", slog.Float64("intensity", cfg.Intensity))
+
+	imageRef := image.Ref
+
+	if err := toSRGB(imageRef); err != nil {
+		slog.ErrorContext(ctx, "Failed to convert to sRGB", slog.Any("err", err))
+		return fmt.Errorf("failed to convert to sRGB: %w", err)
+	}
+
+	// Blend the sepia matrix with the identity so intensity fades between the two
+	matrix := make([][]float64, 3)
+	for i := range matrix {
+		matrix[i] = make([]float64, 3)
+		for j := range matrix[i] {
+			identity := 0.0
+			if i == j {
+				identity = 1
+			}
+			matrix[i][j] = cfg.Intensity*sepiaMatrix[i][j] + (1-cfg.Intensity)*identity
+		}
+	}
+
+	// The matrix is 3x3, so it only sees the colour bands of images with alpha
+	err := withoutAlpha(imageRef, func() error {
+		return imageRef.Recomb(matrix)
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to apply sepia", slog.Any("err", err))
+		return fmt.Errorf("failed to apply sepia: %w", err)
+	}
+
+	// Recombination produces floats, casting back clips the highlights the matrix pushes past white
+	if err := imageRef.Cast(vips.BandFormatUchar); err != nil {
+		slog.ErrorContext(ctx, "Failed to cast sepia image", slog.Any("err", err))
+		return fmt.Errorf("failed to cast sepia image: %w", err)
+	}
+
+	return nil
+}
+
+// VipsTintTransformer implements tinting using VIPS
+type VipsTintTransformer struct{}
+
+var _ VipsAnimationTransformer = (*VipsTintTransformer)(nil)
+
+func NewVipsTintTransformer() *VipsTintTransformer {
+	return &VipsTintTransformer{}
+}
+
+func (t *VipsTintTransformer) Name() string {
+	return "tint"
+}
+
+// AppliesToAllFrames is safe as every pixel is adjusted on its own
+func (t *VipsTintTransformer) AppliesToAllFrames() {}
+
+func (t *VipsTintTransformer) ValidateConfig(ctx context.Context, config map[string]any) error {
+	var cfg images.TintConfig
+	if err := mapstructure.Decode(config, &cfg); err != nil {
+		return fmt.Errorf("failed to decode tint config: %w", err)
+	}
+	if err := validate.Struct(cfg); err != nil {
+		return fmt.Errorf("invalid tint config: %w", err)
+	}
+	return nil
+}
+
+func (t *VipsTintTransformer) Transform(ctx context.Context, image []byte, config map[string]any) ([]byte, error) {
+	return transformBuffer(ctx, t, image, config)
+}
+
+func (t *VipsTintTransformer) Apply(ctx context.Context, image *VipsImage, config map[string]any) error {
+	// Decode config
+	var cfg images.TintConfig
+	if err := mapstructure.Decode(config, &cfg); err != nil {
+		return fmt.Errorf("failed to decode tint config: %w", err)
+	}
+
+	slog.DebugContext(ctx, "Applying tint transformation", slog.String("color", cfg.Color))
+
+	color, err := parseHexColor(cfg.Color)
+	if err != nil {
+		return err
+	}
+
+	imageRef := image.Ref
+
+	// Going through grayscale leaves three equal bands holding the luminance
+	if err := imageRef.ToColorSpace(vips.InterpretationBW); err != nil {
+		slog.ErrorContext(ctx, "Failed to convert to grayscale", slog.Any("err", err))
+		return fmt.Errorf("failed to convert to grayscale: %w", err)
+	}
+	if err := imageRef.ToColorSpace(vips.InterpretationSRGB); err != nil {
+		slog.ErrorContext(ctx, "Failed to convert to sRGB", slog.Any("err", err))
+		return fmt.Errorf("failed to convert to sRGB: %w", err)
+	}
+
+	multipliers := make([]float64, imageRef.Bands())
+	offsets := make([]float64, imageRef.Bands())
+	for i := range multipliers {
+		multipliers[i] = 1
+	}
+	multipliers[0] = float64(color.R) / 255
+	multipliers[1] = float64(color.G) / 255
+	multipliers[2] = float64(color.B) / 255
+
+	if err := imageRef.Linear(multipliers, offsets); err != nil {
+		slog.ErrorContext(ctx, "Failed to tint image", slog.Any("err", err))
+		return fmt.Errorf("failed to tint image: %w", err)
+	}
+	if err := imageRef.Cast(vips.BandFormatUchar); err != nil {
+		slog.ErrorContext(ctx, "Failed to cast tinted image", slog.Any("err", err))
+		return fmt.Errorf("failed to cast tinted image: %w", err)
+	}
+
+	return nil
+}
+
+// VipsNegateTransformer implements colour inversion using VIPS
+type VipsNegateTransformer struct{}
+
+var _ VipsAnimationTransformer = (*VipsNegateTransformer)(nil)
+
+func NewVipsNegateTransformer() *VipsNegateTransformer {
+	return &VipsNegateTransformer{}
+}
+
+func (t *VipsNegateTransformer) Name() string {
+	return "negate"
+}
+
+// AppliesToAllFrames is safe as every pixel is adjusted on its own
+func (t *VipsNegateTransformer) AppliesToAllFrames() {}
+
+func (t *VipsNegateTransformer) ValidateConfig(ctx context.Context, config map[string]any) error {
+	var cfg images.NegateConfig
+	if err := mapstructure.Decode(config, &cfg); err != nil {
+		return fmt.Errorf("failed to decode negate config: %w", err)
+	}
+	if err := validate.Struct(cfg); err != nil {
+		return fmt.Errorf("invalid negate config: %w", err)
+	}
+	return nil
+}
+
+func (t *VipsNegateTransformer) Transform(ctx context.Context, image []byte, config map[string]any) ([]byte, error) {
+	return transformBuffer(ctx, t, image, config)
+}
+
+func (t *VipsNegateTransformer) Apply(ctx context.Context, image *VipsImage, config map[string]any) error {
+	// Decode config
+	var cfg images.NegateConfig
+	if err := mapstructure.Decode(config, &cfg); err != nil {
+		return fmt.Errorf("failed to decode negate config: %w", err)
+	}
+
+	slog.DebugContext(ctx, "Applying negate transformation", slog.Bool("alpha", cfg.Alpha))
+
+	imageRef := image.Ref
+
+	var err error
+	if cfg.Alpha {
+		err = imageRef.Invert()
+	} else {
+		err = withoutAlpha(imageRef, imageRef.Invert)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to negate image", slog.Any("err", err))
+		return fmt.Errorf("failed to negate image: %w", err)
+	}
+
+	return nil
+}
+
+// withoutAlpha runs op on the colour bands only and joins the untouched alpha band back afterwards
+func withoutAlpha(imageRef *vips.ImageRef, op func() error) error {
+	if !imageRef.HasAlpha() {
+		return op()
+	}
+
+	alphaBand := imageRef.Bands() - 1
+
+	alpha, err := imageRef.ExtractBandToImage(alphaBand, 1)
+	if err != nil {
+		return fmt.Errorf("failed to extract alpha channel: %w", err)
+	}
+	defer alpha.Close()
+
+	if err := imageRef.ExtractBand(0, alphaBand); err != nil {
+		return fmt.Errorf("failed to extract colour channels: %w", err)
+	}
+
+	if err := op(); err != nil {
+		return err
+	}
+
+	return imageRef.BandJoin(alpha)
+}
+
+// toSRGB converts grayscale and other non sRGB images to 8 bit sRGB, keeping any alpha band
+func toSRGB(imageRef *vips.ImageRef) error {
+	if imageRef.Interpretation() == vips.InterpretationSRGB {
+		return nil
+	}
+
+	return imageRef.ToColorSpace(vips.InterpretationSRGB)
+}
@@ -0,0 +1,74 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/taldoflemis/sora-henkan/internal/core/domain/images"
+	"github.com/taldoflemis/sora-henkan/internal/core/ports"
+)
+
+func init() {
+	registerVipsTransformer(func(_ TransformerDependencies) ports.TransformerRegistration {
+		return ports.TransformerRegistration{
+			Transformer:  NewVipsFrameTransformer(),
+			Aliases:      []string{"poster"},
+			RenderArgs:   []string{"index"},
+			Description:  "Turns an animated GIF or WebP into a still of one of its frames, the first one by default",
+			ConfigSchema: configSchema(images.FrameConfig{}),
+			Examples:     []map[string]any{{}, {"index": 4}},
+		}
+	})
+}
+
+// VipsFrameTransformer turns an animated image into a still of a single frame using VIPS
+type VipsFrameTransformer struct{}
+
+var _ VipsAnimationTransformer = (*VipsFrameTransformer)(nil)
+
+func NewVipsFrameTransformer() *VipsFrameTransformer {
+	return &VipsFrameTransformer{}
+}
+
+func (t *VipsFrameTransformer) Name() string {
+	return "frame"
+}
+
+// AppliesToAllFrames lets the frame step pick its frame out of the whole animation
+func (t *VipsFrameTransformer) AppliesToAllFrames() {}
+
+func (t *VipsFrameTransformer) ValidateConfig(ctx context.Context, config map[string]any) error {
+	var cfg images.FrameConfig
+	if err := mapstructure.Decode(config, &cfg); err != nil {
+		return fmt.Errorf("failed to decode frame config: %w", err)
+	}
+	if err := validate.Struct(cfg); err != nil {
+		return fmt.Errorf("invalid frame config: %w", err)
+	}
+	return nil
+}
+
+func (t *VipsFrameTransformer) Transform(ctx context.Context, image []byte, config map[string]any) ([]byte, error) {
+	return transformBuffer(ctx, t, image, config)
+}
+
+func (t *VipsFrameTransformer) Apply(ctx context.Context, image *VipsImage, config map[string]any) error {
+	// Decode config
+	var cfg images.FrameConfig
+	if err := mapstructure.Decode(config, &cfg); err != nil {
+		return fmt.Errorf("failed to decode frame config: %w", err)
+	}
+
+	slog.DebugContext(ctx, "Applying frame transformation",
+		slog.Int("index", cfg.Index),
+		slog.Int("frames", image.Frames()))
+
+	if err := image.KeepFrame(cfg.Index); err != nil {
+		slog.ErrorContext(ctx, "Failed to extract frame", slog.Any("err", err))
+		return fmt.Errorf("failed to extract frame: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package image
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/davidbyttow/govips/v2/vips"
+)
+
+// parseHexColor parses #rgb, #rgba, #rrggbb and #rrggbbaa colours, alpha defaults to opaque
+func parseHexColor(hex string) (*vips.ColorRGBA, error) {
+	digits := strings.TrimPrefix(hex, "#")
+
+	// Expand the short forms so every channel has two digits
+	if len(digits) == 3 || len(digits) == 4 {
+		expanded := make([]byte, 0, len(digits)*2)
+		for i := range len(digits) {
+			expanded = append(expanded, digits[i], digits[i])
+		}
+		digits = string(expanded)
+	}
+
+	if len(digits) == 6 {
+		digits += "ff"
+	}
+	if len(digits) != 8 {
+		return nil, fmt.Errorf("invalid hex colour: %q", hex)
+	}
+
+	value, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex colour: %q", hex)
+	}
+
+	return &vips.ColorRGBA{
+		R: uint8(value >> 24),
+		G: uint8(value >> 16),
+		B: uint8(value >> 8),
+		A: uint8(value),
+	}, nil
+}
+
+// embedBackground places the image at left, top on a width x height canvas filled with background
+func embedBackground(imageRef *vips.ImageRef, left, top, width, height int, background string) error {
+	color, err := backgroundColor(imageRef, background)
+	if err != nil {
+		return err
+	}
+
+	return imageRef.EmbedBackgroundRGBA(left, top, width, height, color)
+}
+
+// backgroundColor parses the colour new pixels are filled with, opaque black when empty.
+// A translucent colour adds an alpha channel to the image so it can show through.
+func backgroundColor(imageRef *vips.ImageRef, background string) (*vips.ColorRGBA, error) {
+	color := &vips.ColorRGBA{R: 0, G: 0, B: 0, A: 255}
+	if background != "" {
+		parsed, err := parseHexColor(background)
+		if err != nil {
+			return nil, err
+		}
+		color = parsed
+	}
+
+	if color.A < 255 && !imageRef.HasAlpha() {
+		if err := imageRef.AddAlpha(); err != nil {
+			return nil, fmt.Errorf("failed to add alpha channel: %w", err)
+		}
+	}
+
+	return color, nil
+}
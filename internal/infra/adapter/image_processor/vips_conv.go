@@ -0,0 +1,92 @@
+package image
+
+// govips has no binding for vips_conv and keeps the VipsImage of an ImageRef to itself,
+// so the pixels are handed to vips_conv through memory and come back as an uncompressed TIFF,
+// which keeps every band format.
+
+// #cgo pkg-config: vips
+// #include <stdlib.h>
+// #include <vips/vips.h>
+//
+// static int conv_matrix(const void *data, size_t len, int width, int height, int bands, int format,
+//     const double *kernel, int kernel_width, int kernel_height, double scale, double offset,
+//     void **buf, size_t *buf_len) {
+//   VipsImage *in, *mask, *conv, *cast;
+//   int err;
+//
+//   in = vips_image_new_from_memory_copy(data, len, width, height, bands, format);
+//   if (!in) {
+//     return -1;
+//   }
+//
+//   mask = vips_image_new_matrix_from_array(kernel_width, kernel_height, kernel,
+//       kernel_width * kernel_height);
+//   if (!mask) {
+//     g_object_unref(in);
+//     return -1;
+//   }
+//   vips_image_set_double(mask, "scale", scale);
+//   vips_image_set_double(mask, "offset", offset);
+//
+//   err = vips_conv(in, &conv, mask, "precision", VIPS_PRECISION_FLOAT, NULL);
+//   g_object_unref(mask);
+//   g_object_unref(in);
+//   if (err) {
+//     return err;
+//   }
+//
+//   err = vips_cast(conv, &cast, format, NULL);
+//   g_object_unref(conv);
+//   if (err) {
+//     return err;
+//   }
+//
+//   err = vips_tiffsave_buffer(cast, buf, buf_len, NULL);
+//   g_object_unref(cast);
+//   return err;
+// }
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+
+	"github.com/davidbyttow/govips/v2/vips"
+)
+
+// convolve replaces every pixel with the weighted sum of its neighbourhood, divided by scale and offset.
+// Edge pixels are convolved with copies of the border, and the sum is cast back to the format of the
+// image, which clips it to its range.
+func convolve(imageRef *vips.ImageRef, kernel [][]float64, scale, offset float64) error {
+	data, err := imageRef.ToBytes()
+	if err != nil {
+		return err
+	}
+
+	weights := make([]float64, 0, len(kernel)*len(kernel[0]))
+	for _, row := range kernel {
+		weights = append(weights, row...)
+	}
+
+	var buf unsafe.Pointer
+	var length C.size_t
+
+	if C.conv_matrix(unsafe.Pointer(&data[0]), C.size_t(len(data)),
+		C.int(imageRef.Width()), C.int(imageRef.Height()), C.int(imageRef.Bands()), C.int(imageRef.BandFormat()),
+		(*C.double)(unsafe.Pointer(&weights[0])), C.int(len(kernel[0])), C.int(len(kernel)),
+		C.double(scale), C.double(offset), &buf, &length) != 0 {
+		message := C.GoString(C.vips_error_buffer())
+		C.vips_error_clear()
+		return errors.New(message)
+	}
+	defer C.g_free(C.gpointer(buf))
+
+	convolved, err := vips.NewImageFromBuffer(C.GoBytes(buf, C.int(length)))
+	if err != nil {
+		return err
+	}
+	defer convolved.Close()
+
+	// Inserting the result over the whole image replaces the pixels and keeps its interpretation
+	return imageRef.Insert(convolved, 0, 0, false, nil)
+}
@@ -0,0 +1,167 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/taldoflemis/sora-henkan/internal/core/domain/images"
+	"github.com/taldoflemis/sora-henkan/internal/core/ports"
+)
+
+func init() {
+	registerVipsTransformer(func(_ TransformerDependencies) ports.TransformerRegistration {
+		return ports.TransformerRegistration{
+			Transformer:  NewVipsSharpenTransformer(),
+			RenderArgs:   []string{"sigma", "x1", "m2"},
+			Description:  "Sharpens the image with an unsharp mask",
+			ConfigSchema: configSchema(defaultSharpenConfig),
+			Examples:     []map[string]any{{"sigma": 1, "m2": 4}},
+		}
+	})
+
+	registerVipsTransformer(func(_ TransformerDependencies) ports.TransformerRegistration {
+		return ports.TransformerRegistration{
+			Transformer:  NewVipsConvolveTransformer(),
+			Description:  "Convolves the image with a kernel of up to 7x7",
+			ConfigSchema: configSchema(images.ConvolveConfig{}),
+			Examples: []map[string]any{
+				{"kernel": [][]float64{{-1, -1, -1}, {-1, 8, -1}, {-1, -1, -1}}, "scale": 1, "offset": 128},
+			},
+		}
+	})
+}
+
+// VipsSharpenTransformer implements sharpening using VIPS
+type VipsSharpenTransformer struct{}
+
+var _ VipsImageTransformer = (*VipsSharpenTransformer)(nil)
+
+// defaultSharpenConfig holds the vips sharpen defaults
+var defaultSharpenConfig = images.SharpenConfig{
+	Sigma: 0.5,
+	X1:    2,
+	M2:    3,
+}
+
+func NewVipsSharpenTransformer() *VipsSharpenTransformer {
+	return &VipsSharpenTransformer{}
+}
+
+func (t *VipsSharpenTransformer) Name() string {
+	return "sharpen"
+}
+
+func (t *VipsSharpenTransformer) ValidateConfig(ctx context.Context, config map[string]any) error {
+	var cfg images.SharpenConfig
+	if err := mapstructure.Decode(config, &cfg); err != nil {
+		return fmt.Errorf("failed to decode sharpen config: %w", err)
+	}
+	if err := validate.Struct(cfg); err != nil {
+		return fmt.Errorf("invalid sharpen config: %w", err)
+	}
+	return nil
+}
+
+func (t *VipsSharpenTransformer) Transform(ctx context.Context, image []byte, config map[string]any) ([]byte, error) {
+	return transformBuffer(ctx, t, image, config)
+}
+
+func (t *VipsSharpenTransformer) Apply(ctx context.Context, image *VipsImage, config map[string]any) error {
+	// Decode config with the vips defaults
+	cfg := defaultSharpenConfig
+	if err := mapstructure.Decode(config, &cfg); err != nil {
+		return fmt.Errorf("failed to decode sharpen config: %w", err)
+	}
+
+	slog.DebugContext(ctx, "Applying sharpen transformation",
+		slog.Float64("sigma", cfg.Sigma),
+		slog.Float64("x1", cfg.X1),
+		slog.Float64("m2", cfg.M2))
+
+	err := image.Ref.Sharpen(cfg.Sigma, cfg.X1, cfg.M2)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to sharpen image", slog.Any("err", err))
+		return fmt.Errorf("failed to sharpen image: %w", err)
+	}
+
+	return nil
+}
+
+// VipsConvolveTransformer convolves the image with a user supplied kernel using VIPS
+type VipsConvolveTransformer struct{}
+
+var _ VipsImageTransformer = (*VipsConvolveTransformer)(nil)
+
+func NewVipsConvolveTransformer() *VipsConvolveTransformer {
+	return &VipsConvolveTransformer{}
+}
+
+func (t *VipsConvolveTransformer) Name() string {
+	return "convolve"
+}
+
+func (t *VipsConvolveTransformer) ValidateConfig(ctx context.Context, config map[string]any) error {
+	var cfg images.ConvolveConfig
+	if err := mapstructure.Decode(config, &cfg); err != nil {
+		return fmt.Errorf("failed to decode convolve config: %w", err)
+	}
+	if err := validate.Struct(cfg); err != nil {
+		return fmt.Errorf("invalid convolve config: %w", err)
+	}
+
+	// The tags bound the kernel size, its shape is checked here
+	height, width := len(cfg.Kernel), len(cfg.Kernel[0])
+	for _, row := range cfg.Kernel {
+		if len(row) != width {
+			return fmt.Errorf("invalid convolve config: kernel rows must all have the same length")
+		}
+	}
+	if width%2 == 0 || height%2 == 0 {
+		return fmt.Errorf("invalid convolve config: kernel is %dx%d, both dimensions must be odd", width, height)
+	}
+
+	return nil
+}
+
+func (t *VipsConvolveTransformer) Transform(ctx context.Context, image []byte, config map[string]any) ([]byte, error) {
+	return transformBuffer(ctx, t, image, config)
+}
+
+func (t *VipsConvolveTransformer) Apply(ctx context.Context, image *VipsImage, config map[string]any) error {
+	// Decode config
+	var cfg images.ConvolveConfig
+	if err := mapstructure.Decode(config, &cfg); err != nil {
+		return fmt.Errorf("failed to decode convolve config: %w", err)
+	}
+
+	if cfg.Scale == 0 {
+		for _, row := range cfg.Kernel {
+			for _, weight := range row {
+				cfg.Scale += weight
+			}
+		}
+	}
+	if cfg.Scale == 0 {
+		cfg.Scale = 1
+	}
+
+	slog.DebugContext(ctx, "Applying convolve transformation",
+		slog.Int("width", len(cfg.Kernel[0])),
+		slog.Int("height", len(cfg.Kernel)),
+		slog.Float64("scale", cfg.Scale),
+		slog.Float64("offset", cfg.Offset))
+
+	imageRef := image.Ref
+
+	err := withoutAlpha(imageRef, func() error {
+		return convolve(imageRef, cfg.Kernel, cfg.Scale, cfg.Offset)
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to convolve image", slog.Any("err", err))
+		return fmt.Errorf("failed to convolve image: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/davidbyttow/govips/v2/vips"
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/taldoflemis/sora-henkan/internal/core/domain/images"
+	"github.com/taldoflemis/sora-henkan/internal/core/ports"
+)
+
+func init() {
+	registerVipsTransformer(func(_ TransformerDependencies) ports.TransformerRegistration {
+		return ports.TransformerRegistration{
+			Transformer:  NewVipsExtendTransformer(),
+			Aliases:      []string{"pad"},
+			RenderArgs:   []string{images.RenderArgSize, "gravity"},
+			Description:  "Adds borders around the image, or places it on a larger canvas",
+			ConfigSchema: configSchema(defaultExtendConfig),
+			Examples: []map[string]any{
+				{"top": 20, "right": 20, "bottom": 20, "left": 20, "background": "#ffffff"},
+				{"width": 1080, "height": 1080, "mode": "mirror"},
+			},
+		}
+	})
+}
+
+// VipsExtendTransformer implements padding, borders and canvas extension using VIPS
+type VipsExtendTransformer struct{}
+
+var _ VipsImageTransformer = (*VipsExtendTransformer)(nil)
+
+// defaultExtendConfig centres the image on a solid canvas
+var defaultExtendConfig = images.ExtendConfig{
+	Gravity: "centre",
+	Mode:    "solid",
+}
+
+func NewVipsExtendTransformer() *VipsExtendTransformer {
+	return &VipsExtendTransformer{}
+}
+
+func (t *VipsExtendTransformer) Name() string {
+	return "extend"
+}
+
+func (t *VipsExtendTransformer) ValidateConfig(ctx context.Context, config map[string]any) error {
+	var cfg images.ExtendConfig
+	if err := mapstructure.Decode(config, &cfg); err != nil {
+		return fmt.Errorf("failed to decode extend config: %w", err)
+	}
+	if err := validate.Struct(cfg); err != nil {
+		return fmt.Errorf("invalid extend config: %w", err)
+	}
+	return nil
+}
+
+func (t *VipsExtendTransformer) Transform(ctx context.Context, image []byte, config map[string]any) ([]byte, error) {
+	return transformBuffer(ctx, t, image, config)
+}
+
+func (t *VipsExtendTransformer) Apply(ctx context.Context, image *VipsImage, config map[string]any) error {
+	// Decode config with default values
+	cfg := defaultExtendConfig
+	if err := mapstructure.Decode(config, &cfg); err != nil {
+		return fmt.Errorf("failed to decode extend config: %w", err)
+	}
+
+	slog.DebugContext(ctx, "Applying extend transformation",
+		slog.Int("top", cfg.Top),
+		slog.Int("right", cfg.Right),
+		slog.Int("bottom", cfg.Bottom),
+		slog.Int("left", cfg.Left),
+		slog.Int("width", cfg.Width),
+		slog.Int("height", cfg.Height),
+		slog.String("mode", cfg.Mode))
+
+	imageRef := image.Ref
+	imageWidth, imageHeight := imageRef.Width(), imageRef.Height()
+
+	left, top := cfg.Left, cfg.Top
+	width, height := imageWidth+cfg.Left+cfg.Right, imageHeight+cfg.Top+cfg.Bottom
+
+	if cfg.Width > 0 || cfg.Height > 0 {
+		// A dimension left out or smaller than the image keeps the image size
+		width, height = max(cfg.Width, imageWidth), max(cfg.Height, imageHeight)
+		left, top = gravityOffset(cfg.Gravity, width-imageWidth, height-imageHeight)
+	}
+
+	if width > images.MaxCanvasSize || height > images.MaxCanvasSize {
+		return fmt.Errorf("%w: %dx%d is over the %dx%d limit", images.ErrCanvasTooLarge,
+			width, height, images.MaxCanvasSize, images.MaxCanvasSize)
+	}
+
+	if width == imageWidth && height == imageHeight {
+		return nil
+	}
+
+	var err error
+	if cfg.Mode == "solid" {
+		err = embedBackground(imageRef, left, top, width, height, cfg.Background)
+	} else {
+		err = imageRef.Embed(left, top, width, height, extendStrategy(cfg.Mode))
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to extend image", slog.Any("err", err))
+		return fmt.Errorf("failed to extend image: %w", err)
+	}
+
+	return nil
+}
+
+// extendStrategy maps an extend mode to the VIPS strategy that fills the new area from the image edges
+func extendStrategy(mode string) vips.ExtendStrategy {
+	switch mode {
+	case "mirror":
+		return vips.ExtendMirror
+	case "repeat":
+		return vips.ExtendRepeat
+	default:
+		return vips.ExtendCopy
+	}
+}
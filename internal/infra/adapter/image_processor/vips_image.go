@@ -0,0 +1,345 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/davidbyttow/govips/v2/vips"
+	"github.com/taldoflemis/sora-henkan/internal/core/domain/images"
+	"github.com/taldoflemis/sora-henkan/internal/core/ports"
+)
+
+// VipsImage is a decoded image shared by every step of a pipeline,
+// so it is decoded once before the first step and encoded once after the last one.
+// Animated images hold every frame, stacked vertically in a strip of frames one page height tall.
+type VipsImage struct {
+	Ref *vips.ImageRef
+
+	// encode overrides how the image is exported, the format step sets it.
+	// When nil the image is exported in the format it was loaded from.
+	encode func(ref *vips.ImageRef) ([]byte, error)
+
+	// splitPages exports every page as an output of its own instead of as an animation
+	splitPages bool
+}
+
+// LoadOptions decide how an image is decoded
+type LoadOptions struct {
+	Params *vips.ImportParams
+
+	// SplitPages exports every loaded page as an output of its own
+	SplitPages bool
+}
+
+// defaultLoadOptions decodes GIF and WebP images with all their frames, other formats with their first page
+func defaultLoadOptions(image []byte) *LoadOptions {
+	params := vips.NewImportParams()
+	if isAnimatedType(vips.DetermineImageType(image)) {
+		params.NumPages.Set(-1)
+	}
+
+	return &LoadOptions{Params: params}
+}
+
+// NewVipsImage decodes an image so it can be passed between transformers
+func NewVipsImage(image []byte) (*VipsImage, error) {
+	return loadVipsImage(image, defaultLoadOptions(image))
+}
+
+// loadVipsImage decodes an image with the options a load step picked
+func loadVipsImage(image []byte, options *LoadOptions) (*VipsImage, error) {
+	imageRef, err := vips.LoadImageFromBuffer(image, options.Params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load image: %w", err)
+	}
+
+	return &VipsImage{Ref: imageRef, splitPages: options.SplitPages}, nil
+}
+
+// Export encodes the image with the last format step applied, or in its original format
+func (i *VipsImage) Export() ([]byte, error) {
+	if i.encode != nil {
+		return i.encode(i.Ref)
+	}
+
+	output, _, err := i.Ref.ExportNative()
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// ExportPages encodes every page on its own when a page range was loaded, like Export does for the image.
+// It returns nil for other images, which are exported whole.
+func (i *VipsImage) ExportPages() ([][]byte, error) {
+	if !i.splitPages || i.Frames() == 1 {
+		return nil, nil
+	}
+
+	frames, err := i.splitFrames()
+	if err != nil {
+		return nil, fmt.Errorf("failed to split pages: %w", err)
+	}
+	defer closeFrames(frames)
+
+	pages := make([][]byte, 0, len(frames))
+	for _, frame := range frames {
+		page, err := (&VipsImage{Ref: frame, encode: i.encode}).Export()
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, page)
+	}
+
+	return pages, nil
+}
+
+// Close releases the decoded image
+func (i *VipsImage) Close() {
+	i.Ref.Close()
+}
+
+// Frames returns the number of frames of the image, 1 for a still image
+func (i *VipsImage) Frames() int {
+	pageHeight := i.Ref.PageHeight()
+	if pageHeight <= 0 || pageHeight >= i.Ref.Height() {
+		return 1
+	}
+	return i.Ref.Height() / pageHeight
+}
+
+// KeepFrame turns an animated image into a still of the frame at index, counting from 0
+func (i *VipsImage) KeepFrame(index int) error {
+	frames := i.Frames()
+	if index >= frames {
+		return fmt.Errorf("%w: frame %d was asked for, the image has %d", images.ErrFrameOutOfRange, index, frames)
+	}
+	if frames == 1 {
+		return nil
+	}
+
+	frame, err := extractFrame(i.Ref, index)
+	if err != nil {
+		return err
+	}
+
+	i.Ref.Close()
+	i.Ref = frame
+
+	return nil
+}
+
+// splitFrames copies every frame of an animated image into a still image of its own
+func (i *VipsImage) splitFrames() ([]*vips.ImageRef, error) {
+	frames := make([]*vips.ImageRef, 0, i.Frames())
+
+	for index := range i.Frames() {
+		frame, err := extractFrame(i.Ref, index)
+		if err != nil {
+			closeFrames(frames)
+			return nil, err
+		}
+		frames = append(frames, frame)
+	}
+
+	return frames, nil
+}
+
+// joinFrames replaces the image with frames stacked back into a strip,
+// keeping the frame delays and loop count of the animation
+func (i *VipsImage) joinFrames(frames []*vips.ImageRef) error {
+	width, height := frames[0].Width(), frames[0].Height()
+	for index, frame := range frames[1:] {
+		if frame.Width() != width || frame.Height() != height {
+			return fmt.Errorf("%w: frame 0 is %dx%d and frame %d is %dx%d", images.ErrAnimationFramesDiffer,
+				width, height, index+1, frame.Width(), frame.Height())
+		}
+	}
+
+	delays, err := i.Ref.PageDelay()
+	if err != nil {
+		return fmt.Errorf("failed to read frame delays: %w", err)
+	}
+	loop := i.Ref.GetInt("loop")
+
+	joined, err := frames[0].Copy()
+	if err != nil {
+		return err
+	}
+
+	err = joined.ArrayJoin(frames[1:], 1)
+	if err == nil {
+		err = joined.SetPages(len(frames))
+	}
+	if err == nil {
+		err = joined.SetPageHeight(height)
+	}
+	if err == nil && len(delays) == len(frames) {
+		err = joined.SetPageDelay(delays)
+	}
+	if err != nil {
+		joined.Close()
+		return fmt.Errorf("failed to join frames: %w", err)
+	}
+	joined.SetInt("loop", loop)
+
+	i.Ref.Close()
+	i.Ref = joined
+
+	return nil
+}
+
+// extractFrame copies the frame at index out of a strip of frames as a still image
+func extractFrame(imageRef *vips.ImageRef, index int) (*vips.ImageRef, error) {
+	pageHeight := imageRef.PageHeight()
+
+	frame, err := imageRef.Copy()
+	if err != nil {
+		return nil, err
+	}
+
+	// With a single page the extract is a plain one instead of one applied to every frame
+	err = frame.SetPageHeight(frame.Height())
+	if err == nil {
+		err = frame.ExtractArea(0, index*pageHeight, frame.Width(), pageHeight)
+	}
+	if err == nil {
+		err = frame.SetPages(1)
+	}
+
+	return closeOnError(frame, err)
+}
+
+// closeFrames releases frames split out of an animation
+func closeFrames(frames []*vips.ImageRef) {
+	for _, frame := range frames {
+		frame.Close()
+	}
+}
+
+// isAnimatedType reports whether images of a type can hold an animation
+func isAnimatedType(imageType vips.ImageType) bool {
+	return imageType == vips.ImageTypeGIF || imageType == vips.ImageTypeWEBP
+}
+
+// VipsImageTransformer is implemented by transformers that can work on a shared decoded image.
+// The pipeline prefers Apply over Transform, which decodes and encodes the image on every call.
+type VipsImageTransformer interface {
+	ports.ImageTransformer
+
+	// Apply transforms the image in place.
+	Apply(ctx context.Context, image *VipsImage, config map[string]any) error
+}
+
+// VipsImageLoader is implemented by transformers that decide how the image is decoded.
+// The pipeline decodes the image with their options, so their step has to be the first one.
+type VipsImageLoader interface {
+	VipsImageTransformer
+
+	// LoadOptions returns the options to decode the encoded image with
+	LoadOptions(ctx context.Context, image []byte, config map[string]any) (*LoadOptions, error)
+}
+
+// VipsAnimationTransformer is implemented by transformers that can be applied to every frame of an
+// animated image at once, because they work pixel by pixel or handle the frames themselves.
+// Other transformers are applied to each frame in turn.
+type VipsAnimationTransformer interface {
+	VipsImageTransformer
+
+	// AppliesToAllFrames marks the transformer as safe to apply to the whole strip of frames
+	AppliesToAllFrames()
+}
+
+// VipsFramePreparer is implemented by transformers with work that is the same for every frame of an
+// animated image, such as loading an overlay. The step is prepared once and then applied to each frame.
+type VipsFramePreparer interface {
+	VipsImageTransformer
+
+	// Prepare does the work shared by the frames, frame has the size all of them have
+	Prepare(ctx context.Context, frame *VipsImage, config map[string]any) (VipsPreparedStep, error)
+}
+
+// VipsPreparedStep is a step prepared for frames of one size
+type VipsPreparedStep interface {
+	// ApplyToFrame transforms a frame in place
+	ApplyToFrame(ctx context.Context, frame *VipsImage) error
+
+	// Close releases what was prepared
+	Close()
+}
+
+// applyTransformer applies a transformer to the image, frame by frame when the image is animated
+func applyTransformer(ctx context.Context, transformer VipsImageTransformer, image *VipsImage, config map[string]any) error {
+	if _, ok := transformer.(VipsAnimationTransformer); ok || image.Frames() == 1 {
+		return transformer.Apply(ctx, image, config)
+	}
+
+	frames, err := image.splitFrames()
+	if err != nil {
+		return fmt.Errorf("failed to split frames: %w", err)
+	}
+	// The joined image keeps its own references to the frames
+	defer closeFrames(frames)
+
+	apply := func(frame *VipsImage) error {
+		return transformer.Apply(ctx, frame, config)
+	}
+
+	if preparer, ok := transformer.(VipsFramePreparer); ok {
+		step, err := preparer.Prepare(ctx, &VipsImage{Ref: frames[0], encode: image.encode}, config)
+		if err != nil {
+			return err
+		}
+		defer step.Close()
+
+		apply = func(frame *VipsImage) error {
+			return step.ApplyToFrame(ctx, frame)
+		}
+	}
+
+	for index := range frames {
+		frame := &VipsImage{Ref: frames[index], encode: image.encode}
+		err := apply(frame)
+		// Apply may have swapped the frame for a new image
+		frames[index] = frame.Ref
+		if err != nil {
+			return err
+		}
+		image.encode = frame.encode
+	}
+
+	return image.joinFrames(frames)
+}
+
+// transformBuffer runs a single Apply on encoded bytes, for callers that use a transformer on its own
+func transformBuffer(ctx context.Context, transformer VipsImageTransformer, image []byte, config map[string]any) ([]byte, error) {
+	options := defaultLoadOptions(image)
+	if loader, ok := transformer.(VipsImageLoader); ok {
+		var err error
+		options, err = loader.LoadOptions(ctx, image, config)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	vipsImage, err := loadVipsImage(image, options)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to load image", slog.Any("err", err))
+		return nil, err
+	}
+	defer vipsImage.Close()
+
+	if err := applyTransformer(ctx, transformer, vipsImage, config); err != nil {
+		return nil, err
+	}
+
+	output, err := vipsImage.Export()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to export image", slog.Any("err", err))
+		return nil, fmt.Errorf("failed to export image: %w", err)
+	}
+
+	return output, nil
+}
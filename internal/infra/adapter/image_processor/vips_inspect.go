@@ -0,0 +1,258 @@
+package image
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/davidbyttow/govips/v2/vips"
+	"github.com/taldoflemis/sora-henkan/internal/core/domain/images"
+	"github.com/taldoflemis/sora-henkan/internal/core/ports"
+)
+
+// maxEXIFValueLength drops EXIF values such as maker notes and thumbnails, which are blobs rather than text
+const maxEXIFValueLength = 256
+
+// The difference hash compares each pixel of a dHashWidth x dHashHeight thumbnail with its right neighbour
+const (
+	dHashWidth  = 9
+	dHashHeight = 8
+)
+
+// VipsImageInspector reads image properties from the image header using VIPS
+type VipsImageInspector struct{}
+
+var _ ports.ImageInspector = (*VipsImageInspector)(nil)
+
+func NewVipsImageInspector() *VipsImageInspector {
+	return &VipsImageInspector{}
+}
+
+// Inspect only decodes the header of the first page, so it is cheap even for large documents and animations
+func (i *VipsImageInspector) Inspect(ctx context.Context, image []byte) (*images.ImageProperties, error) {
+	header, err := vips.NewImageFromBuffer(image)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load image: %w", err)
+	}
+	defer header.Close()
+
+	properties := &images.ImageProperties{
+		Width:       header.Width(),
+		Height:      header.PageHeight(),
+		Size:        int64(len(image)),
+		ColorSpace:  colorSpaceName(header.Interpretation()),
+		BitDepth:    bitDepth(header.BandFormat()),
+		HasAlpha:    header.HasAlpha(),
+		Pages:       max(header.Pages(), 1),
+		Orientation: header.GetOrientation(),
+		EXIF:        exifTags(header.GetExif()),
+	}
+
+	if header.HasICCProfile() {
+		properties.ICCProfile = iccProfileDescription(header.GetICCProfile())
+	}
+
+	slog.DebugContext(ctx, "Inspected image",
+		slog.Int("width", properties.Width),
+		slog.Int("height", properties.Height),
+		slog.String("color_space", properties.ColorSpace),
+		slog.Int("pages", properties.Pages),
+		slog.Int("exif_tags", len(properties.EXIF)))
+
+	return properties, nil
+}
+
+// colorSpaceName names an interpretation the way libvips does
+func colorSpaceName(interpretation vips.Interpretation) string {
+	switch interpretation {
+	case vips.InterpretationMultiband:
+		return "multiband"
+	case vips.InterpretationBW:
+		return "b-w"
+	case vips.InterpretationHistogram:
+		return "histogram"
+	case vips.InterpretationXYZ:
+		return "xyz"
+	case vips.InterpretationLAB:
+		return "lab"
+	case vips.InterpretationCMYK:
+		return "cmyk"
+	case vips.InterpretationLABQ:
+		return "labq"
+	case vips.InterpretationRGB:
+		return "rgb"
+	case vips.InterpretationRGB16:
+		return "rgb16"
+	case vips.InterpretationCMC:
+		return "cmc"
+	case vips.InterpretationLCH:
+		return "lch"
+	case vips.InterpretationLABS:
+		return "labs"
+	case vips.InterpretationSRGB:
+		return "srgb"
+	case vips.InterpretationYXY:
+		return "yxy"
+	case vips.InterpretationFourier:
+		return "fourier"
+	case vips.InterpretationGrey16:
+		return "grey16"
+	case vips.InterpretationMatrix:
+		return "matrix"
+	case vips.InterpretationScRGB:
+		return "scrgb"
+	case vips.InterpretationHSV:
+		return "hsv"
+	default:
+		return "unknown"
+	}
+}
+
+// bitDepth returns the number of bits each band of a pixel is stored in
+func bitDepth(format vips.BandFormat) int {
+	switch format {
+	case vips.BandFormatUchar, vips.BandFormatChar:
+		return 8
+	case vips.BandFormatUshort, vips.BandFormatShort:
+		return 16
+	case vips.BandFormatUint, vips.BandFormatInt, vips.BandFormatFloat:
+		return 32
+	case vips.BandFormatDouble, vips.BandFormatComplex:
+		return 64
+	case vips.BandFormatDpComplex:
+		return 128
+	default:
+		return 0
+	}
+}
+
+// exifTags strips the exif- prefix off the tag names libvips reports and the type
+// it appends to their values, turning "Canon (Canon, ASCII, 6 components, 6 bytes)" into "Canon"
+func exifTags(fields map[string]string) map[string]string {
+	tags := make(map[string]string, len(fields))
+	for field, value := range fields {
+		// exif-data is the raw EXIF block, the other fields are decoded from it
+		if field == "exif-data" {
+			continue
+		}
+
+		if pos := strings.LastIndex(value, " ("); pos != -1 {
+			value = value[:pos]
+		}
+		if value == "" || len(value) > maxEXIFValueLength {
+			continue
+		}
+
+		tags[strings.TrimPrefix(field, "exif-")] = value
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+	return tags
+}
+
+// iccProfileDescription reads the desc tag of an ICC profile, which holds an ASCII string
+// in version 2 profiles and UTF-16 localized strings in version 4 ones
+func iccProfileDescription(profile []byte) string {
+	// The tag table follows the 128 byte header, each entry is a signature, an offset and a size
+	if len(profile) < 132 {
+		return ""
+	}
+
+	tagCount := int(binary.BigEndian.Uint32(profile[128:132]))
+	for i := range tagCount {
+		entry := 132 + i*12
+		if entry+12 > len(profile) {
+			return ""
+		}
+		if string(profile[entry:entry+4]) != "desc" {
+			continue
+		}
+
+		offset := int(binary.BigEndian.Uint32(profile[entry+4 : entry+8]))
+		size := int(binary.BigEndian.Uint32(profile[entry+8 : entry+12]))
+		if offset < 0 || size < 12 || offset+size > len(profile) {
+			return ""
+		}
+		tag := profile[offset : offset+size]
+
+		switch string(tag[:4]) {
+		case "desc":
+			length := int(binary.BigEndian.Uint32(tag[8:12]))
+			if 12+length > len(tag) {
+				return ""
+			}
+			return strings.TrimRight(string(tag[12:12+length]), "\x00")
+		case "mluc":
+			// The first record is enough, every record translates the same description
+			if len(tag) < 28 || binary.BigEndian.Uint32(tag[8:12]) == 0 {
+				return ""
+			}
+			length := int(binary.BigEndian.Uint32(tag[20:24]))
+			start := int(binary.BigEndian.Uint32(tag[24:28]))
+			if start+length > len(tag) {
+				return ""
+			}
+
+			units := make([]uint16, 0, length/2)
+			for j := start; j+1 < start+length; j += 2 {
+				units = append(units, binary.BigEndian.Uint16(tag[j:j+2]))
+			}
+			return strings.TrimRight(string(utf16.Decode(units)), "\x00")
+		}
+		return ""
+	}
+
+	return ""
+}
+
+// PerceptualHash computes the difference hash of the first page of an image. The image is shrunk to a grey
+// thumbnail, and each bit tells whether a pixel is brighter than the next one, so copies that were resized,
+// recompressed or slightly recoloured hash the same or a few bits apart.
+func (i *VipsImageInspector) PerceptualHash(ctx context.Context, image []byte) (string, error) {
+	thumbnail, err := vips.NewThumbnailWithSizeFromBuffer(image, dHashWidth, dHashHeight, vips.InterestingNone, vips.SizeForce)
+	if err != nil {
+		return "", fmt.Errorf("failed to load image: %w", err)
+	}
+	defer thumbnail.Close()
+
+	// Transparent areas are compared as the white they are usually shown on
+	if thumbnail.HasAlpha() {
+		if err := thumbnail.Flatten(&vips.Color{R: 255, G: 255, B: 255}); err != nil {
+			return "", fmt.Errorf("failed to flatten image: %w", err)
+		}
+	}
+	if err := thumbnail.ToColorSpace(vips.InterpretationBW); err != nil {
+		return "", fmt.Errorf("failed to convert image to grey: %w", err)
+	}
+	if err := thumbnail.Cast(vips.BandFormatUchar); err != nil {
+		return "", fmt.Errorf("failed to cast image: %w", err)
+	}
+
+	pixels, err := thumbnail.ToBytes()
+	if err != nil {
+		return "", fmt.Errorf("failed to read image pixels: %w", err)
+	}
+	if len(pixels) < dHashWidth*dHashHeight {
+		return "", fmt.Errorf("thumbnail has %d pixels, %d were expected", len(pixels), dHashWidth*dHashHeight)
+	}
+
+	var hash uint64
+	for y := range dHashHeight {
+		row := pixels[y*dHashWidth : (y+1)*dHashWidth]
+		for x := range dHashWidth - 1 {
+			hash <<= 1
+			if row[x] > row[x+1] {
+				hash |= 1
+			}
+		}
+	}
+
+	slog.DebugContext(ctx, "Computed perceptual hash", slog.String("hash", images.FormatPerceptualHash(hash)))
+
+	return images.FormatPerceptualHash(hash), nil
+}
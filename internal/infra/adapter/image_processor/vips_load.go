@@ -0,0 +1,159 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+
+	"github.com/davidbyttow/govips/v2/vips"
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/taldoflemis/sora-henkan/internal/core/domain/images"
+	"github.com/taldoflemis/sora-henkan/internal/core/ports"
+)
+
+func init() {
+	registerVipsTransformer(func(_ TransformerDependencies) ports.TransformerRegistration {
+		return ports.TransformerRegistration{
+			Transformer:     NewVipsLoadTransformer(),
+			Aliases:         []string{"page", "density"},
+			RenderArgs:      []string{"page", "dpi"},
+			AliasRenderArgs: map[string][]string{"density": {"dpi"}},
+			Description:     "Picks the pages of a PDF, TIFF or animation to load and the density PDF and SVG images are rasterized at, as the first step",
+			ConfigSchema:    configSchema(images.LoadConfig{}),
+			Examples: []map[string]any{
+				{"page": 2, "dpi": 150},
+				{"page_range": "1-10"},
+				{"width": 1024},
+			},
+		}
+	})
+}
+
+// defaultDPI is the density vips rasterizes PDF and SVG images at when none is given
+const defaultDPI = 72
+
+// VipsLoadTransformer picks the pages and density an image is decoded with using VIPS
+type VipsLoadTransformer struct{}
+
+var (
+	_ VipsImageLoader          = (*VipsLoadTransformer)(nil)
+	_ VipsAnimationTransformer = (*VipsLoadTransformer)(nil)
+)
+
+func NewVipsLoadTransformer() *VipsLoadTransformer {
+	return &VipsLoadTransformer{}
+}
+
+func (t *VipsLoadTransformer) Name() string {
+	return "load"
+}
+
+// AppliesToAllFrames is safe as Apply leaves the image as it was decoded
+func (t *VipsLoadTransformer) AppliesToAllFrames() {}
+
+func (t *VipsLoadTransformer) ValidateConfig(ctx context.Context, config map[string]any) error {
+	var cfg images.LoadConfig
+	if err := mapstructure.Decode(config, &cfg); err != nil {
+		return fmt.Errorf("failed to decode load config: %w", err)
+	}
+	if err := validate.Struct(cfg); err != nil {
+		return fmt.Errorf("invalid load config: %w", err)
+	}
+	if cfg.PageRange != "" {
+		if _, _, err := images.ParsePageRange(cfg.PageRange); err != nil {
+			return fmt.Errorf("invalid load config: %w", err)
+		}
+	}
+	return nil
+}
+
+func (t *VipsLoadTransformer) Transform(ctx context.Context, image []byte, config map[string]any) ([]byte, error) {
+	return transformBuffer(ctx, t, image, config)
+}
+
+// LoadOptions reads the page count and size of the image from its header to pick the pages and density it is decoded with
+func (t *VipsLoadTransformer) LoadOptions(ctx context.Context, image []byte, config map[string]any) (*LoadOptions, error) {
+	// Decode config
+	var cfg images.LoadConfig
+	if err := mapstructure.Decode(config, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode load config: %w", err)
+	}
+
+	imageType := vips.DetermineImageType(image)
+
+	// Only the header is read, pixels are decoded when an operation needs them
+	header, err := vips.NewImageFromBuffer(image)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load image: %w", err)
+	}
+	defer header.Close()
+
+	options := defaultLoadOptions(image)
+	params := options.Params
+
+	pages := header.Pages()
+	switch {
+	case cfg.PageRange != "":
+		first, count, err := images.ParsePageRange(cfg.PageRange)
+		if err != nil {
+			return nil, err
+		}
+		if first > pages {
+			return nil, fmt.Errorf("%w: page %d was asked for, the image has %d", images.ErrPageOutOfRange, first, pages)
+		}
+
+		// vips counts pages from 0
+		params.Page.Set(first - 1)
+		params.NumPages.Set(min(count, pages-first+1))
+		options.SplitPages = true
+	case cfg.Page > 0:
+		if cfg.Page > pages {
+			return nil, fmt.Errorf("%w: page %d was asked for, the image has %d", images.ErrPageOutOfRange, cfg.Page, pages)
+		}
+
+		params.Page.Set(cfg.Page - 1)
+		params.NumPages.Set(1)
+	}
+
+	if (cfg.DPI > 0 || cfg.Width > 0) && isVectorType(imageType) {
+		dpi := cfg.DPI
+		if cfg.Width > 0 {
+			dpi = int(math.Ceil(float64(defaultDPI*cfg.Width) / float64(header.Width())))
+			dpi = min(max(dpi, 1), images.MaxLoadDPI)
+		}
+
+		// The header is rasterized at the default density, which scales linearly
+		width := header.Width() * dpi / defaultDPI
+		height := header.PageHeight() * dpi / defaultDPI
+		if width > images.MaxCanvasSize || height > images.MaxCanvasSize {
+			return nil, fmt.Errorf("%w: %dx%d at %d dpi is over the %dx%d limit", images.ErrCanvasTooLarge,
+				width, height, dpi, images.MaxCanvasSize, images.MaxCanvasSize)
+		}
+
+		params.Density.Set(dpi)
+	}
+
+	slog.DebugContext(ctx, "Picked load options",
+		slog.Int("page", cfg.Page),
+		slog.String("page_range", cfg.PageRange),
+		slog.Int("pages", pages),
+		slog.Bool("split_pages", options.SplitPages))
+
+	return options, nil
+}
+
+// Apply has nothing left to do, the pipeline decoded the image with the options of LoadOptions
+func (t *VipsLoadTransformer) Apply(ctx context.Context, image *VipsImage, config map[string]any) error {
+	slog.DebugContext(ctx, "Applying load transformation",
+		slog.Int("frames", image.Frames()),
+		slog.Int("width", image.Ref.Width()),
+		slog.Int("height", image.Ref.PageHeight()))
+
+	return nil
+}
+
+// isVectorType reports whether images of a type are rasterized at a density when they are decoded
+func isVectorType(imageType vips.ImageType) bool {
+	return imageType == vips.ImageTypePDF || imageType == vips.ImageTypeSVG
+}
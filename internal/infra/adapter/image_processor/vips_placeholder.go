@@ -0,0 +1,280 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/davidbyttow/govips/v2/vips"
+	"github.com/taldoflemis/sora-henkan/internal/core/domain/images"
+)
+
+const (
+	// placeholderSize bounds the thumbnail the placeholder is computed from, which is plenty for a blur
+	placeholderSize = 32
+
+	// The BlurHash keeps blurHashComponentsX x blurHashComponentsY cosine components, enough for a few soft shapes
+	blurHashComponentsX = 4
+	blurHashComponentsY = 3
+
+	// paletteSize is the number of colours picked for the palette
+	paletteSize = 5
+
+	// paletteIterations is how many times the palette colours are refined towards the pixels closest to them
+	paletteIterations = 10
+)
+
+// rgb is a pixel of the placeholder thumbnail
+type rgb [3]float64
+
+// Placeholder computes a BlurHash, the dominant colour and a colour palette from a thumbnail of the first page of an image
+func (i *VipsImageInspector) Placeholder(ctx context.Context, image []byte) (*images.ImagePlaceholder, error) {
+	pixels, width, height, err := placeholderPixels(image)
+	if err != nil {
+		return nil, err
+	}
+
+	palette := paletteColors(pixels, paletteSize)
+
+	placeholder := &images.ImagePlaceholder{
+		BlurHash:      blurHash(pixels, width, height, blurHashComponentsX, blurHashComponentsY),
+		DominantColor: palette[0],
+		Palette:       palette,
+	}
+
+	slog.DebugContext(ctx, "Computed image placeholder",
+		slog.String("blurhash", placeholder.BlurHash),
+		slog.String("dominant_color", placeholder.DominantColor),
+		slog.Int("palette", len(placeholder.Palette)))
+
+	return placeholder, nil
+}
+
+// placeholderPixels shrinks an image to an sRGB thumbnail at most placeholderSize wide and tall and reads its pixels
+func placeholderPixels(image []byte) ([]rgb, int, int, error) {
+	thumbnail, err := vips.NewThumbnailWithSizeFromBuffer(image, placeholderSize, placeholderSize, vips.InterestingNone, vips.SizeDown)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to load image: %w", err)
+	}
+	defer thumbnail.Close()
+
+	// Transparent areas are shown on white, so that is what the placeholder shows too
+	if thumbnail.HasAlpha() {
+		if err := thumbnail.Flatten(&vips.Color{R: 255, G: 255, B: 255}); err != nil {
+			return nil, 0, 0, fmt.Errorf("failed to flatten image: %w", err)
+		}
+	}
+	if err := thumbnail.ToColorSpace(vips.InterpretationSRGB); err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to convert image to sRGB: %w", err)
+	}
+	if err := thumbnail.Cast(vips.BandFormatUchar); err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to cast image: %w", err)
+	}
+
+	data, err := thumbnail.ToBytes()
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to read image pixels: %w", err)
+	}
+
+	width, height, bands := thumbnail.Width(), thumbnail.Height(), thumbnail.Bands()
+	if bands < 3 || len(data) < width*height*bands {
+		return nil, 0, 0, fmt.Errorf("thumbnail has %d bytes for %dx%d pixels of %d bands", len(data), width, height, bands)
+	}
+
+	pixels := make([]rgb, width*height)
+	for p := range pixels {
+		offset := p * bands
+		pixels[p] = rgb{float64(data[offset]), float64(data[offset+1]), float64(data[offset+2])}
+	}
+
+	return pixels, width, height, nil
+}
+
+// paletteColors clusters the pixels into at most size colours with k-means and returns them as hex colours,
+// the colour covering most pixels first. The starting colours are picked as far apart as possible,
+// so the same image always gets the same palette.
+func paletteColors(pixels []rgb, size int) []string {
+	// Start from the average colour, then keep adding the pixel furthest from every colour picked so far
+	var mean rgb
+	for _, pixel := range pixels {
+		for c := range mean {
+			mean[c] += pixel[c] / float64(len(pixels))
+		}
+	}
+
+	centroids := []rgb{mean}
+	for len(centroids) < size {
+		furthest, furthestDistance := rgb{}, 0.0
+		for _, pixel := range pixels {
+			if distance := colorDistance(pixel, centroids[nearestCentroid(pixel, centroids)]); distance > furthestDistance {
+				furthest, furthestDistance = pixel, distance
+			}
+		}
+
+		// Images with fewer distinct colours than size get a shorter palette
+		if furthestDistance == 0 {
+			break
+		}
+		centroids = append(centroids, furthest)
+	}
+
+	counts := make([]int, len(centroids))
+	for range paletteIterations {
+		sums := make([]rgb, len(centroids))
+		clear(counts)
+
+		for _, pixel := range pixels {
+			nearest := nearestCentroid(pixel, centroids)
+			counts[nearest]++
+			for c := range pixel {
+				sums[nearest][c] += pixel[c]
+			}
+		}
+
+		for k := range centroids {
+			if counts[k] == 0 {
+				continue
+			}
+			for c := range centroids[k] {
+				centroids[k][c] = sums[k][c] / float64(counts[k])
+			}
+		}
+	}
+
+	order := make([]int, 0, len(centroids))
+	for k := range centroids {
+		if counts[k] > 0 {
+			order = append(order, k)
+		}
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return counts[b] - counts[a]
+	})
+
+	palette := make([]string, 0, len(order))
+	for _, k := range order {
+		palette = append(palette, fmt.Sprintf("#%02x%02x%02x",
+			int(math.Round(centroids[k][0])), int(math.Round(centroids[k][1])), int(math.Round(centroids[k][2]))))
+	}
+	return palette
+}
+
+// nearestCentroid returns the index of the centroid closest to a pixel
+func nearestCentroid(pixel rgb, centroids []rgb) int {
+	nearest, nearestDistance := 0, math.Inf(1)
+	for k, centroid := range centroids {
+		if distance := colorDistance(pixel, centroid); distance < nearestDistance {
+			nearest, nearestDistance = k, distance
+		}
+	}
+	return nearest
+}
+
+// colorDistance is the squared Euclidean distance between two colours
+func colorDistance(a, b rgb) float64 {
+	return (a[0]-b[0])*(a[0]-b[0]) + (a[1]-b[1])*(a[1]-b[1]) + (a[2]-b[2])*(a[2]-b[2])
+}
+
+// blurHashCharacters are the digits of the base 83 encoding BlurHash strings use
+const blurHashCharacters = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+// blurHash encodes pixels as a BlurHash (https://blurha.sh), a short string clients decode into a blurred
+// preview. It holds the average colour and the first componentsX x componentsY cosine components of the image.
+func blurHash(pixels []rgb, width int, height int, componentsX int, componentsY int) string {
+	factors := make([]rgb, 0, componentsX*componentsY)
+	for j := range componentsY {
+		for i := range componentsX {
+			normalisation := 2.0
+			if i == 0 && j == 0 {
+				normalisation = 1
+			}
+
+			var factor rgb
+			for y := range height {
+				for x := range width {
+					basis := math.Cos(math.Pi*float64(i)*float64(x)/float64(width)) *
+						math.Cos(math.Pi*float64(j)*float64(y)/float64(height))
+					pixel := pixels[y*width+x]
+					for c := range factor {
+						factor[c] += basis * srgbToLinear(pixel[c])
+					}
+				}
+			}
+
+			scale := normalisation / float64(width*height)
+			for c := range factor {
+				factor[c] *= scale
+			}
+			factors = append(factors, factor)
+		}
+	}
+
+	var hash strings.Builder
+	hash.WriteString(encodeBase83((componentsX-1)+(componentsY-1)*9, 1))
+
+	dc, ac := factors[0], factors[1:]
+
+	maximumValue := 1.0
+	if len(ac) > 0 {
+		actualMaximum := 0.0
+		for _, factor := range ac {
+			for _, value := range factor {
+				actualMaximum = max(actualMaximum, math.Abs(value))
+			}
+		}
+
+		quantisedMaximum := int(max(0, min(82, math.Floor(actualMaximum*166-0.5))))
+		maximumValue = float64(quantisedMaximum+1) / 166
+		hash.WriteString(encodeBase83(quantisedMaximum, 1))
+	} else {
+		hash.WriteString(encodeBase83(0, 1))
+	}
+
+	hash.WriteString(encodeBase83(linearToSRGB(dc[0])<<16+linearToSRGB(dc[1])<<8+linearToSRGB(dc[2]), 4))
+
+	for _, factor := range ac {
+		quantised := 0
+		for _, value := range factor {
+			quantised = quantised*19 + int(max(0, min(18, math.Floor(signPow(value/maximumValue, 0.5)*9+9.5))))
+		}
+		hash.WriteString(encodeBase83(quantised, 2))
+	}
+
+	return hash.String()
+}
+
+// encodeBase83 writes value as length base 83 digits
+func encodeBase83(value int, length int) string {
+	digits := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		digits[i] = blurHashCharacters[value%83]
+		value /= 83
+	}
+	return string(digits)
+}
+
+// srgbToLinear converts an sRGB channel from 0 to 255 to linear light from 0 to 1
+func srgbToLinear(value float64) float64 {
+	v := value / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// linearToSRGB converts linear light from 0 to 1 to an sRGB channel from 0 to 255
+func linearToSRGB(value float64) int {
+	v := max(0, min(1, value))
+	if v <= 0.0031308 {
+		return int(v*12.92*255 + 0.5)
+	}
+	return int((1.055*math.Pow(v, 1/2.4)-0.055)*255 + 0.5)
+}
+
+// signPow raises the magnitude of value to exp, keeping its sign
+func signPow(value float64, exp float64) float64 {
+	return math.Copysign(math.Pow(math.Abs(value), exp), value)
+}
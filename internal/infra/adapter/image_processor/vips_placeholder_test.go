@@ -0,0 +1,155 @@
+package image
+
+import (
+	"slices"
+	"testing"
+)
+
+// pixelGrid fills a width x height grid, row by row, with the colour of each position
+func pixelGrid(width int, height int, color func(x, y int) rgb) []rgb {
+	pixels := make([]rgb, 0, width*height)
+	for y := range height {
+		for x := range width {
+			pixels = append(pixels, color(x, y))
+		}
+	}
+	return pixels
+}
+
+// TestBlurHash compares with the hashes the reference encoder (github.com/woltapp/blurhash, encode.c)
+// computes for the same grids
+func TestBlurHash(t *testing.T) {
+	tests := []struct {
+		name        string
+		width       int
+		height      int
+		componentsX int
+		componentsY int
+		color       func(x, y int) rgb
+		want        string
+	}{
+		{
+			name:  "uniform",
+			width: 8, height: 6, componentsX: 4, componentsY: 3,
+			color: func(x, y int) rgb { return rgb{128, 128, 128} },
+			want:  "LBEyb[_3fQ_3~qt7fQt7fQfQfQfQ",
+		},
+		{
+			name:  "single component",
+			width: 4, height: 4, componentsX: 1, componentsY: 1,
+			color: func(x, y int) rgb { return rgb{255, 0, 0} },
+			want:  "00TI:j",
+		},
+		{
+			name:  "horizontal gradient",
+			width: 8, height: 4, componentsX: 4, componentsY: 3,
+			color: func(x, y int) rgb {
+				v := float64(x * 255 / 7)
+				return rgb{v, v, v}
+			},
+			want: "LyI5Y-00fQxu-;IUfQoffQfQfQfQ",
+		},
+		{
+			name:  "quadrants",
+			width: 6, height: 6, componentsX: 3, componentsY: 3,
+			color: func(x, y int) rgb {
+				quadrants := []rgb{{255, 0, 0}, {0, 255, 0}, {0, 0, 255}, {255, 255, 0}}
+				quadrant := 0
+				if x >= 3 {
+					quadrant++
+				}
+				if y >= 3 {
+					quadrant += 2
+				}
+				return quadrants[quadrant]
+			},
+			want: "K[Lqdfz8dj%B{d+ufL,Twu",
+		},
+		{
+			name:  "pattern",
+			width: 5, height: 7, componentsX: blurHashComponentsX, componentsY: blurHashComponentsY,
+			color: func(x, y int) rgb {
+				return rgb{float64((x*37 + y*91) % 256), float64((x * 53) % 256), float64((y * 29) % 256)}
+			},
+			want: "LbF=,5z5ih.6%ANGWDotWnJkfQjs",
+		},
+		{
+			name:  "most components",
+			width: 12, height: 10, componentsX: 9, componentsY: 9,
+			color: func(x, y int) rgb {
+				return rgb{float64((x * 21) % 256), float64((y * 25) % 256), float64(((x ^ y) * 16) % 256)}
+			},
+			want: "|nG8_,2*sTt8N=xbN@xaN?uoRijwi~a^j0a}i{a_fzfofMfgfUfhfOflfSxaScjvj^a_j]a}j?a{eXf4fTf6fOf8fOf9fOxuShjrj=b0j=a^j^a{eBf4fTf9fNf9fSf5fPx@Sijqj=b0j?a{j[a}eBf5fSf8fPf8fRf6fO",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pixels := pixelGrid(tt.width, tt.height, tt.color)
+
+			got := blurHash(pixels, tt.width, tt.height, tt.componentsX, tt.componentsY)
+			if got != tt.want {
+				t.Errorf("blurHash() = %q, want %q", got, tt.want)
+			}
+
+			// One size digit, one maximum digit, four for the average colour and two for each other component
+			if wantLength := 6 + 2*(tt.componentsX*tt.componentsY-1); len(got) != wantLength {
+				t.Errorf("blurHash() has %d characters, want %d", len(got), wantLength)
+			}
+		})
+	}
+}
+
+func TestPaletteColors(t *testing.T) {
+	red, blue := rgb{255, 0, 0}, rgb{0, 0, 255}
+
+	tests := []struct {
+		name   string
+		pixels []rgb
+		size   int
+		want   []string
+	}{
+		{
+			name:   "one colour",
+			pixels: pixelGrid(4, 4, func(x, y int) rgb { return rgb{51, 102, 204} }),
+			size:   paletteSize,
+			want:   []string{"#3366cc"},
+		},
+		{
+			name: "two colours",
+			pixels: pixelGrid(4, 4, func(x, y int) rgb {
+				if y == 0 {
+					return blue
+				}
+				return red
+			}),
+			size: paletteSize,
+			want: []string{"#ff0000", "#0000ff"},
+		},
+		{
+			name: "two colours, most pixels last",
+			pixels: pixelGrid(4, 4, func(x, y int) rgb {
+				if y == 0 && x < 2 {
+					return red
+				}
+				return blue
+			}),
+			size: paletteSize,
+			want: []string{"#0000ff", "#ff0000"},
+		},
+		{
+			name:   "two colours in one",
+			pixels: pixelGrid(2, 1, func(x, y int) rgb { return []rgb{red, blue}[x] }),
+			size:   1,
+			want:   []string{"#800080"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := paletteColors(tt.pixels, tt.size); !slices.Equal(got, tt.want) {
+				t.Errorf("paletteColors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/davidbyttow/govips/v2/vips"
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/taldoflemis/sora-henkan/internal/core/domain/images"
+	"github.com/taldoflemis/sora-henkan/internal/core/ports"
+)
+
+func init() {
+	registerVipsTransformer(func(deps TransformerDependencies) ports.TransformerRegistration {
+		return ports.TransformerRegistration{
+			Transformer: NewVipsTextTransformer(deps.FontsDir),
+			RenderArgs:  []string{"text", "gravity"},
+			Description: "Draws a caption onto the image with one of the bundled fonts",
+			ConfigSchema: configSchema(images.TextConfig{
+				FontFamily: defaultFontFamily,
+				FontSize:   defaultFontSize,
+				Color:      "#000000",
+				Align:      "left",
+			}),
+			Examples: []map[string]any{
+				{"text": "Hello, world", "gravity": "south", "margin": 24},
+				{"text": "Sale", "font_weight": "bold", "color": "#ffffff", "background": "#cc0000", "padding": 8},
+			},
+		}
+	})
+}
+
+const (
+	defaultFontFamily = "sans"
+	defaultFontSize   = 32
+)
+
+// textFont is a bundled font, by family name and the files of its weights
+type textFont struct {
+	name string
+	file string
+	bold string
+}
+
+// textFonts are the DejaVu fonts shipped with the worker image
+var textFonts = map[string]textFont{
+	"sans":  {name: "DejaVu Sans", file: "DejaVuSans.ttf", bold: "DejaVuSans-Bold.ttf"},
+	"serif": {name: "DejaVu Serif", file: "DejaVuSerif.ttf", bold: "DejaVuSerif-Bold.ttf"},
+	"mono":  {name: "DejaVu Sans Mono", file: "DejaVuSansMono.ttf", bold: "DejaVuSansMono-Bold.ttf"},
+}
+
+// VipsTextTransformer draws a caption onto the image using VIPS
+type VipsTextTransformer struct {
+	fontsDir string
+}
+
+var _ VipsFramePreparer = (*VipsTextTransformer)(nil)
+
+func NewVipsTextTransformer(fontsDir string) *VipsTextTransformer {
+	return &VipsTextTransformer{
+		fontsDir: fontsDir,
+	}
+}
+
+func (t *VipsTextTransformer) Name() string {
+	return "text"
+}
+
+func (t *VipsTextTransformer) ValidateConfig(ctx context.Context, config map[string]any) error {
+	var cfg images.TextConfig
+	if err := mapstructure.Decode(config, &cfg); err != nil {
+		return fmt.Errorf("failed to decode text config: %w", err)
+	}
+	if err := validate.Struct(cfg); err != nil {
+		return fmt.Errorf("invalid text config: %w", err)
+	}
+	return nil
+}
+
+func (t *VipsTextTransformer) Transform(ctx context.Context, image []byte, config map[string]any) ([]byte, error) {
+	return transformBuffer(ctx, t, image, config)
+}
+
+func (t *VipsTextTransformer) Apply(ctx context.Context, image *VipsImage, config map[string]any) error {
+	step, err := t.Prepare(ctx, image, config)
+	if err != nil {
+		return err
+	}
+	defer step.Close()
+
+	return step.ApplyToFrame(ctx, image)
+}
+
+// Prepare renders the caption once and places it for the frame size, so animations don't render it for every frame
+func (t *VipsTextTransformer) Prepare(ctx context.Context, frame *VipsImage, config map[string]any) (VipsPreparedStep, error) {
+	// Decode config
+	var cfg images.TextConfig
+	if err := mapstructure.Decode(config, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode text config: %w", err)
+	}
+
+	if cfg.FontFamily == "" {
+		cfg.FontFamily = defaultFontFamily
+	}
+	if cfg.FontSize == 0 {
+		cfg.FontSize = defaultFontSize
+	}
+
+	slog.DebugContext(ctx, "Applying text transformation",
+		slog.Int("length", len(cfg.Text)),
+		slog.String("font_family", cfg.FontFamily),
+		slog.Int("font_size", cfg.FontSize),
+		slog.String("font_weight", cfg.FontWeight),
+		slog.String("gravity", cfg.Gravity),
+		slog.Int("max_width", cfg.MaxWidth))
+
+	imageRef := frame.Ref
+
+	color := &vips.ColorRGBA{R: 0, G: 0, B: 0, A: 255}
+	if cfg.Color != "" {
+		parsed, err := parseHexColor(cfg.Color)
+		if err != nil {
+			return nil, err
+		}
+		color = parsed
+	}
+
+	maxWidth := cfg.MaxWidth
+	if maxWidth == 0 {
+		maxWidth = max(imageRef.Width()-2*(cfg.Margin+cfg.Padding), 0)
+	}
+
+	font := textFonts[cfg.FontFamily]
+	description := fmt.Sprintf("%s %d", font.name, cfg.FontSize)
+	fontFile := font.file
+	if cfg.FontWeight == "bold" {
+		description = fmt.Sprintf("%s Bold %d", font.name, cfg.FontSize)
+		fontFile = font.bold
+	}
+
+	// vips_text reads Pango markup, so the text is escaped and the colour set on a span around it
+	markup := fmt.Sprintf(`<span foreground="#%02x%02x%02x%02x">%s</span>`,
+		color.R, color.G, color.B, color.A, html.EscapeString(cfg.Text))
+
+	// vips_text allocates the whole caption while rendering, so one that can't fit is refused before that
+	estimatedWidth, estimatedHeight := estimateCaption(cfg.Text, cfg.FontSize, maxWidth)
+	estimatedWidth, estimatedHeight = estimatedWidth+2*cfg.Padding, estimatedHeight+2*cfg.Padding
+	if estimatedWidth > images.MaxCanvasSize || estimatedHeight > images.MaxCanvasSize {
+		return nil, fmt.Errorf("%w: caption of about %dx%d is over the %dx%d limit", images.ErrCanvasTooLarge,
+			estimatedWidth, estimatedHeight, images.MaxCanvasSize, images.MaxCanvasSize)
+	}
+
+	caption, err := renderText(markup, description, filepath.Join(t.fontsDir, fontFile), maxWidth, textAlign(cfg.Align))
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to render text", slog.Any("err", err))
+		return nil, fmt.Errorf("failed to render text: %w", err)
+	}
+
+	// The estimate is only as good as the font metrics it assumes, so the rendered caption is checked too
+	width, height := caption.Width()+2*cfg.Padding, caption.Height()+2*cfg.Padding
+	if width > images.MaxCanvasSize || height > images.MaxCanvasSize {
+		caption.Close()
+		return nil, fmt.Errorf("%w: caption of %dx%d is over the %dx%d limit", images.ErrCanvasTooLarge,
+			width, height, images.MaxCanvasSize, images.MaxCanvasSize)
+	}
+
+	if cfg.Background != "" || cfg.Padding > 0 {
+		boxed, err := textBox(caption, cfg.Background, cfg.Padding)
+		caption.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to draw text background", slog.Any("err", err))
+			return nil, fmt.Errorf("failed to draw text background: %w", err)
+		}
+		caption = boxed
+	}
+
+	left, top := cfg.Left, cfg.Top
+	if cfg.Gravity != "" {
+		left, top = watermarkPosition(cfg.Gravity, cfg.Margin,
+			imageRef.Width()-caption.Width(), imageRef.Height()-caption.Height())
+	}
+
+	return &preparedOverlay{what: "text", overlay: caption, blend: vips.BlendModeOver, left: left, top: top}, nil
+}
+
+// estimateCaption bounds the size Pango lays text out at without rendering it, taking every character
+// to be an em wide and every line 1.25 em tall. Lines wrap between words at maxWidth when it is positive,
+// and a word wider than that is taken to stay whole on a line of its own.
+func estimateCaption(text string, fontSize int, maxWidth int) (int, int) {
+	width, lines := 0, 0
+	for _, paragraph := range strings.Split(text, "\n") {
+		lines++
+		lineWidth := 0
+		for _, word := range strings.Fields(paragraph) {
+			wordWidth := utf8.RuneCountInString(word) * fontSize
+			if lineWidth > 0 {
+				if maxWidth > 0 && lineWidth+fontSize+wordWidth > maxWidth {
+					lines++
+					lineWidth = 0
+				} else {
+					// The space before the word
+					lineWidth += fontSize
+				}
+			}
+			lineWidth += wordWidth
+			width = max(width, lineWidth)
+		}
+	}
+	return width, lines * fontSize * 5 / 4
+}
+
+// textBox returns the caption padded on every side, with the box behind it filled with background
+func textBox(caption *vips.ImageRef, background string, padding int) (*vips.ImageRef, error) {
+	box, err := caption.Copy()
+	if err != nil {
+		return nil, err
+	}
+
+	err = box.Embed(padding, padding, caption.Width()+2*padding, caption.Height()+2*padding, vips.ExtendBlack)
+	if err != nil || background == "" {
+		return closeOnError(box, err)
+	}
+
+	color, err := parseHexColor(background)
+	if err != nil {
+		return closeOnError(box, err)
+	}
+
+	// Scaling every pixel to nothing and offsetting by the colour fills the whole box
+	fill := []float64{float64(color.R), float64(color.G), float64(color.B), float64(color.A)}
+	if err := box.Linear([]float64{0, 0, 0, 0}, fill); err != nil {
+		return closeOnError(box, err)
+	}
+	if err := box.Cast(vips.BandFormatUchar); err != nil {
+		return closeOnError(box, err)
+	}
+
+	return closeOnError(box, box.Composite(caption, vips.BlendModeOver, padding, padding))
+}
+
+// closeOnError releases the image when err is set, so it is only handed back on success
+func closeOnError(imageRef *vips.ImageRef, err error) (*vips.ImageRef, error) {
+	if err != nil {
+		imageRef.Close()
+		return nil, err
+	}
+	return imageRef, nil
+}
+
+// textAlign maps an alignment name to its VIPS alignment, left being the default
+func textAlign(name string) vips.Align {
+	switch name {
+	case "centre", "center":
+		return vips.AlignCenter
+	case "right":
+		return vips.AlignHigh
+	default:
+		return vips.AlignLow
+	}
+}
@@ -0,0 +1,60 @@
+package image
+
+// govips only exposes vips_text through Label, which always sets a height and so
+// scales the font to fill the label, this calls it directly to keep the font size.
+
+// #cgo pkg-config: vips
+// #include <stdlib.h>
+// #include <vips/vips.h>
+//
+// static int render_text(const char *text, const char *font, const char *fontfile,
+//     int width, int align, void **buf, size_t *len) {
+//   VipsImage *out;
+//   int err;
+//
+//   if (width > 0) {
+//     err = vips_text(&out, text, "font", font, "fontfile", fontfile, "width", width,
+//         "align", align, "dpi", 72, "rgba", TRUE, NULL);
+//   } else {
+//     err = vips_text(&out, text, "font", font, "fontfile", fontfile,
+//         "align", align, "dpi", 72, "rgba", TRUE, NULL);
+//   }
+//   if (err) {
+//     return err;
+//   }
+//
+//   err = vips_pngsave_buffer(out, buf, len, "compression", 1, NULL);
+//   g_object_unref(out);
+//   return err;
+// }
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+
+	"github.com/davidbyttow/govips/v2/vips"
+)
+
+// renderText renders Pango markup to an RGBA image with a transparent background.
+// At 72 DPI the font size in the description is in pixels, lines wrap at width when it is positive.
+func renderText(markup, font, fontFile string, width int, align vips.Align) (*vips.ImageRef, error) {
+	cMarkup := C.CString(markup)
+	defer C.free(unsafe.Pointer(cMarkup))
+	cFont := C.CString(font)
+	defer C.free(unsafe.Pointer(cFont))
+	cFontFile := C.CString(fontFile)
+	defer C.free(unsafe.Pointer(cFontFile))
+
+	var buf unsafe.Pointer
+	var length C.size_t
+
+	if C.render_text(cMarkup, cFont, cFontFile, C.int(width), C.int(align), &buf, &length) != 0 {
+		message := C.GoString(C.vips_error_buffer())
+		C.vips_error_clear()
+		return nil, errors.New(message)
+	}
+	defer C.g_free(C.gpointer(buf))
+
+	return vips.NewImageFromBuffer(C.GoBytes(buf, C.int(length)))
+}
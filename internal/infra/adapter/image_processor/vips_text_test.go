@@ -0,0 +1,50 @@
+package image
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/taldoflemis/sora-henkan/internal/core/domain/images"
+)
+
+func TestEstimateCaption(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		fontSize   int
+		maxWidth   int
+		wantWidth  int
+		wantHeight int
+	}{
+		{name: "one line", text: "Hello, world", fontSize: 48, maxWidth: 1000, wantWidth: 576, wantHeight: 60},
+		{name: "wrapped", text: "Hello, world", fontSize: 48, maxWidth: 400, wantWidth: 288, wantHeight: 120},
+		{name: "line breaks", text: "Hello,\nworld", fontSize: 48, maxWidth: 1000, wantWidth: 288, wantHeight: 120},
+		{name: "no wrapping", text: "Hello, world", fontSize: 48, wantWidth: 576, wantHeight: 60},
+		{name: "word wider than a line", text: "a supercalifragilistic b", fontSize: 10, maxWidth: 100, wantWidth: 200, wantHeight: 37},
+		{name: "empty", text: "", fontSize: 48, maxWidth: 1000, wantWidth: 0, wantHeight: 60},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			width, height := estimateCaption(tt.text, tt.fontSize, tt.maxWidth)
+			if width != tt.wantWidth || height != tt.wantHeight {
+				t.Errorf("estimateCaption() = %dx%d, want %dx%d", width, height, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func TestTextPrepareRefusesHugeCaptions(t *testing.T) {
+	// The longest text at the largest font wraps into far more lines than fit, so nothing gets rendered
+	config := map[string]any{
+		"text":      strings.Repeat("wwwwwwwww ", 100),
+		"font_size": 1000,
+		"max_width": images.MaxCanvasSize,
+	}
+
+	_, err := NewVipsTextTransformer("").Prepare(t.Context(), &VipsImage{}, config)
+	if !errors.Is(err, images.ErrCanvasTooLarge) {
+		t.Errorf("Prepare() error = %v, want %v", err, images.ErrCanvasTooLarge)
+	}
+}
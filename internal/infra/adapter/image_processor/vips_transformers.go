@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math"
 
 	"github.com/davidbyttow/govips/v2/vips"
 	"github.com/go-playground/validator/v10"
@@ -12,12 +13,128 @@ import (
 	"github.com/taldoflemis/sora-henkan/internal/core/ports"
 )
 
+func init() {
+	registerVipsTransformer(func(_ TransformerDependencies) ports.TransformerRegistration {
+		return ports.TransformerRegistration{
+			Transformer:  NewVipsResizeTransformer(),
+			RenderArgs:   []string{images.RenderArgSize, "mode"},
+			Description:  "Scales the image to a width and/or height, fitting it by the given mode",
+			ConfigSchema: configSchema(images.ResizeConfig{Mode: "cover", Kernel: "lanczos3"}),
+			Examples: []map[string]any{
+				{"width": 800},
+				{"width": 400, "height": 400, "mode": "pad", "background": "#ffffff"},
+			},
+		}
+	})
+
+	registerVipsTransformer(func(_ TransformerDependencies) ports.TransformerRegistration {
+		return ports.TransformerRegistration{
+			Transformer:  NewVipsGrayscaleTransformer(),
+			Description:  "Converts the image to shades of grey",
+			ConfigSchema: configSchema(images.GrayscaleConfig{}),
+			Examples:     []map[string]any{{}},
+		}
+	})
+
+	registerVipsTransformer(func(_ TransformerDependencies) ports.TransformerRegistration {
+		return ports.TransformerRegistration{
+			Transformer:  NewVipsTrimTransformer(),
+			RenderArgs:   []string{"threshold", "background"},
+			Description:  "Removes borders matching the background colour, or transparent borders",
+			ConfigSchema: configSchema(defaultTrimConfig),
+			Examples: []map[string]any{
+				{"threshold": 10},
+				{"background": "auto"},
+				{"alpha": true},
+			},
+		}
+	})
+
+	registerVipsTransformer(func(_ TransformerDependencies) ports.TransformerRegistration {
+		return ports.TransformerRegistration{
+			Transformer:  NewVipsBlurTransformer(),
+			RenderArgs:   []string{"sigma"},
+			Description:  "Applies a gaussian blur",
+			ConfigSchema: configSchema(images.BlurConfig{}),
+			Examples:     []map[string]any{{"sigma": 2.5}},
+		}
+	})
+
+	registerVipsTransformer(func(_ TransformerDependencies) ports.TransformerRegistration {
+		return ports.TransformerRegistration{
+			Transformer:  NewVipsRotateTransformer(),
+			RenderArgs:   []string{"angle", "background"},
+			Description:  "Rotates the image clockwise, filling the corners of non right angles with the background",
+			ConfigSchema: configSchema(images.RotateConfig{}),
+			Examples: []map[string]any{
+				{"angle": 90},
+				{"angle": 45, "background": "#00000000"},
+			},
+		}
+	})
+
+	registerVipsTransformer(func(_ TransformerDependencies) ports.TransformerRegistration {
+		return ports.TransformerRegistration{
+			Transformer:  NewVipsFormatTransformer(),
+			Aliases:      []string{"encode"},
+			RenderArgs:   []string{"format", "quality"},
+			Description:  "Sets the format the image is encoded to, and the encoder options",
+			ConfigSchema: configSchema(images.FormatConfig{}),
+			Examples: []map[string]any{
+				{"format": "webp", "quality": 80},
+				{"format": "png", "strip_metadata": true},
+			},
+		}
+	})
+
+	registerVipsTransformer(func(_ TransformerDependencies) ports.TransformerRegistration {
+		return ports.TransformerRegistration{
+			Transformer:  NewVipsCropTransformer(),
+			Aliases:      []string{"extract"},
+			RenderArgs:   []string{images.RenderArgSize, "gravity"},
+			Description:  "Extracts an area of the image, at a position or picked by gravity",
+			ConfigSchema: configSchema(images.CropConfig{}),
+			Examples: []map[string]any{
+				{"left": 10, "top": 10, "width": 200, "height": 100},
+				{"width": 300, "height": 300, "gravity": "attention"},
+			},
+		}
+	})
+
+	registerVipsTransformer(func(_ TransformerDependencies) ports.TransformerRegistration {
+		return ports.TransformerRegistration{
+			Transformer:  NewVipsFlipTransformer(),
+			Description:  "Mirrors the image vertically",
+			ConfigSchema: configSchema(images.FlipConfig{}),
+			Examples:     []map[string]any{{}},
+		}
+	})
+
+	registerVipsTransformer(func(_ TransformerDependencies) ports.TransformerRegistration {
+		return ports.TransformerRegistration{
+			Transformer:  NewVipsFlopTransformer(),
+			Description:  "Mirrors the image horizontally",
+			ConfigSchema: configSchema(images.FlipConfig{}),
+			Examples:     []map[string]any{{}},
+		}
+	})
+
+	registerVipsTransformer(func(_ TransformerDependencies) ports.TransformerRegistration {
+		return ports.TransformerRegistration{
+			Transformer:  NewVipsAutoOrientTransformer(),
+			Description:  "Rotates the image upright according to its EXIF orientation",
+			ConfigSchema: configSchema(images.AutoOrientConfig{}),
+			Examples:     []map[string]any{{}},
+		}
+	})
+}
+
 var validate = validator.New()
 
 // VipsResizeTransformer implements the resize transformation using VIPS
 type VipsResizeTransformer struct{}
 
-var _ ports.ImageTransformer = (*VipsResizeTransformer)(nil)
+var _ VipsImageTransformer = (*VipsResizeTransformer)(nil)
 
 func NewVipsResizeTransformer() *VipsResizeTransformer {
 	return &VipsResizeTransformer{}
@@ -39,69 +156,112 @@ func (t *VipsResizeTransformer) ValidateConfig(ctx context.Context, config map[s
 }
 
 func (t *VipsResizeTransformer) Transform(ctx context.Context, image []byte, config map[string]any) ([]byte, error) {
+	return transformBuffer(ctx, t, image, config)
+}
+
+func (t *VipsResizeTransformer) Apply(ctx context.Context, image *VipsImage, config map[string]any) error {
 	// Decode config
 	var cfg images.ResizeConfig
 	if err := mapstructure.Decode(config, &cfg); err != nil {
-		return nil, fmt.Errorf("failed to decode resize config: %w", err)
+		return fmt.Errorf("failed to decode resize config: %w", err)
+	}
+
+	mode := cfg.Mode
+	switch mode {
+	case "", "crop":
+		mode = "cover"
+	case "contain":
+		mode = "fit"
 	}
 
 	slog.DebugContext(ctx, "Applying resize transformation",
 		slog.Int("width", cfg.Width),
-		slog.Int("height", cfg.Height))
+		slog.Int("height", cfg.Height),
+		slog.String("mode", mode),
+		slog.String("kernel", cfg.Kernel),
+		slog.Bool("without_enlargement", cfg.WithoutEnlargement))
 
-	// Load image to check original dimensions
-	imageRef, err := vips.NewImageFromBuffer(image)
-	if err != nil {
-		slog.ErrorContext(ctx, "Failed to load image", slog.Any("err", err))
-		return nil, fmt.Errorf("failed to load image: %w", err)
-	}
-	defer imageRef.Close()
+	imageRef := image.Ref
 
 	originalWidth := imageRef.Width()
 	originalHeight := imageRef.Height()
 
-	// Determine if we're upscaling
-	isUpscaling := cfg.Width > originalWidth || cfg.Height > originalHeight
+	scaleX := float64(cfg.Width) / float64(originalWidth)
+	scaleY := float64(cfg.Height) / float64(originalHeight)
+
+	// A single dimension keeps the aspect ratio, so there is nothing to fit into
+	switch {
+	case cfg.Width == 0:
+		scaleX, mode = scaleY, "fill"
+	case cfg.Height == 0:
+		scaleY, mode = scaleX, "fill"
+	case mode == "fit" || mode == "pad":
+		scaleX = min(scaleX, scaleY)
+		scaleY = scaleX
+	case mode == "cover":
+		scaleX = max(scaleX, scaleY)
+		scaleY = scaleX
+	}
+
+	if cfg.WithoutEnlargement {
+		scaleX = min(scaleX, 1)
+		scaleY = min(scaleY, 1)
+	}
 
-	if isUpscaling {
-		slog.DebugContext(ctx, "Upscaling detected, using Lanczos3 interpolation",
-			slog.Int("originalWidth", originalWidth),
-			slog.Int("originalHeight", originalHeight))
+	err := imageRef.ResizeWithVScale(scaleX, scaleY, resizeKernel(cfg.Kernel))
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to resize image", slog.Any("err", err))
+		return fmt.Errorf("failed to resize image: %w", err)
+	}
 
-		// Calculate scale factor
-		scaleX := float64(cfg.Width) / float64(originalWidth)
-		scaleY := float64(cfg.Height) / float64(originalHeight)
+	switch mode {
+	case "cover":
+		// Crop the overflow around the centre, the image may be smaller when enlargement is off
+		width := min(cfg.Width, imageRef.Width())
+		height := min(cfg.Height, imageRef.Height())
 
-		// Resize using Lanczos3 interpolation for better upscaling quality
-		err = imageRef.ResizeWithVScale(scaleX, scaleY, vips.KernelLanczos3)
+		err = imageRef.ExtractArea((imageRef.Width()-width)/2, (imageRef.Height()-height)/2, width, height)
 		if err != nil {
-			slog.ErrorContext(ctx, "Failed to resize image with Lanczos3", slog.Any("err", err))
-			return nil, fmt.Errorf("failed to resize image: %w", err)
+			slog.ErrorContext(ctx, "Failed to crop resized image", slog.Any("err", err))
+			return fmt.Errorf("failed to crop resized image: %w", err)
 		}
-	} else {
-		// For downscaling, use the thumbnail method which is optimized
-		imageRef.Close() // Close the previous reference
-		imageRef, err = vips.NewThumbnailFromBuffer(image, cfg.Width, cfg.Height, vips.InterestingCentre)
+
+	case "pad":
+		left := (cfg.Width - imageRef.Width()) / 2
+		top := (cfg.Height - imageRef.Height()) / 2
+
+		err = embedBackground(imageRef, left, top, cfg.Width, cfg.Height, cfg.Background)
 		if err != nil {
-			slog.ErrorContext(ctx, "Failed to create thumbnail", slog.Any("err", err))
-			return nil, fmt.Errorf("failed to create thumbnail: %w", err)
+			slog.ErrorContext(ctx, "Failed to pad resized image", slog.Any("err", err))
+			return fmt.Errorf("failed to pad resized image: %w", err)
 		}
-		defer imageRef.Close()
 	}
 
-	output, _, err := imageRef.ExportNative()
-	if err != nil {
-		slog.ErrorContext(ctx, "Failed to export image", slog.Any("err", err))
-		return nil, fmt.Errorf("failed to export image: %w", err)
-	}
+	return nil
+}
 
-	return output, nil
+// resizeKernel maps a kernel name to its VIPS kernel, lanczos3 being the default
+func resizeKernel(name string) vips.Kernel {
+	switch name {
+	case "nearest":
+		return vips.KernelNearest
+	case "linear":
+		return vips.KernelLinear
+	case "cubic":
+		return vips.KernelCubic
+	case "mitchell":
+		return vips.KernelMitchell
+	case "lanczos2":
+		return vips.KernelLanczos2
+	default:
+		return vips.KernelLanczos3
+	}
 }
 
 // VipsGrayscaleTransformer implements grayscale transformation using VIPS
 type VipsGrayscaleTransformer struct{}
 
-var _ ports.ImageTransformer = (*VipsGrayscaleTransformer)(nil)
+var _ VipsAnimationTransformer = (*VipsGrayscaleTransformer)(nil)
 
 func NewVipsGrayscaleTransformer() *VipsGrayscaleTransformer {
 	return &VipsGrayscaleTransformer{}
@@ -111,41 +271,42 @@ func (t *VipsGrayscaleTransformer) Name() string {
 	return "grayscale"
 }
 
+// AppliesToAllFrames is safe as every pixel is adjusted on its own
+func (t *VipsGrayscaleTransformer) AppliesToAllFrames() {}
+
 func (t *VipsGrayscaleTransformer) ValidateConfig(ctx context.Context, config map[string]any) error {
 	// Grayscale has no required config, always valid
 	return nil
 }
 
 func (t *VipsGrayscaleTransformer) Transform(ctx context.Context, image []byte, config map[string]any) ([]byte, error) {
+	return transformBuffer(ctx, t, image, config)
+}
+
+func (t *VipsGrayscaleTransformer) Apply(ctx context.Context, image *VipsImage, config map[string]any) error {
 	slog.DebugContext(ctx, "Applying grayscale transformation")
 
-	imageRef, err := vips.NewImageFromBuffer(image)
-	if err != nil {
-		slog.ErrorContext(ctx, "Failed to load image", slog.Any("err", err))
-		return nil, fmt.Errorf("failed to load image: %w", err)
-	}
-	defer imageRef.Close()
+	imageRef := image.Ref
 
 	// Convert to grayscale
-	err = imageRef.ToColorSpace(vips.InterpretationBW)
+	err := imageRef.ToColorSpace(vips.InterpretationBW)
 	if err != nil {
 		slog.ErrorContext(ctx, "Failed to convert to grayscale", slog.Any("err", err))
-		return nil, fmt.Errorf("failed to convert to grayscale: %w", err)
+		return fmt.Errorf("failed to convert to grayscale: %w", err)
 	}
 
-	output, _, err := imageRef.ExportNative()
-	if err != nil {
-		slog.ErrorContext(ctx, "Failed to export image", slog.Any("err", err))
-		return nil, fmt.Errorf("failed to export image: %w", err)
-	}
-
-	return output, nil
+	return nil
 }
 
 // VipsTrimTransformer implements edge trimming using VIPS
 type VipsTrimTransformer struct{}
 
-var _ ports.ImageTransformer = (*VipsTrimTransformer)(nil)
+var _ VipsImageTransformer = (*VipsTrimTransformer)(nil)
+
+// defaultTrimConfig is the config trimming starts from before the request config is applied
+var defaultTrimConfig = images.TrimConfig{
+	Threshold: 10.0,
+}
 
 func NewVipsTrimTransformer() *VipsTrimTransformer {
 	return &VipsTrimTransformer{}
@@ -167,51 +328,107 @@ func (t *VipsTrimTransformer) ValidateConfig(ctx context.Context, config map[str
 }
 
 func (t *VipsTrimTransformer) Transform(ctx context.Context, image []byte, config map[string]any) ([]byte, error) {
+	return transformBuffer(ctx, t, image, config)
+}
+
+func (t *VipsTrimTransformer) Apply(ctx context.Context, image *VipsImage, config map[string]any) error {
 	// Decode config with default value
-	var cfg images.TrimConfig
-	cfg.Threshold = 10.0 // Default
+	cfg := defaultTrimConfig
 	if len(config) > 0 {
 		if err := mapstructure.Decode(config, &cfg); err != nil {
-			return nil, fmt.Errorf("failed to decode trim config: %w", err)
+			return fmt.Errorf("failed to decode trim config: %w", err)
 		}
 	}
 
-	slog.DebugContext(ctx, "Applying trim transformation", slog.Float64("threshold", cfg.Threshold))
+	slog.DebugContext(ctx, "Applying trim transformation",
+		slog.Float64("threshold", cfg.Threshold),
+		slog.String("background", cfg.Background),
+		slog.Bool("alpha", cfg.Alpha))
 
-	imageRef, err := vips.NewImageFromBuffer(image)
-	if err != nil {
-		slog.ErrorContext(ctx, "Failed to load image", slog.Any("err", err))
-		return nil, fmt.Errorf("failed to load image: %w", err)
-	}
-	defer imageRef.Close()
+	imageRef := image.Ref
 
-	// Use VIPS FindTrim to detect and remove borders
-	left, top, width, height, err := imageRef.FindTrim(cfg.Threshold, &vips.Color{R: 255, G: 255, B: 255})
+	var left, top, width, height int
+	var err error
+	if cfg.Alpha {
+		left, top, width, height, err = findAlphaTrim(imageRef, cfg.Threshold)
+	} else {
+		left, top, width, height, err = findBackgroundTrim(imageRef, cfg.Threshold, cfg.Background)
+	}
 	if err != nil {
 		slog.ErrorContext(ctx, "Failed to find trim area", slog.Any("err", err))
-		return nil, fmt.Errorf("failed to find trim area: %w", err)
+		return fmt.Errorf("failed to find trim area: %w", err)
+	}
+
+	// FindTrim reports an empty area when nothing differs from the background
+	if width == 0 || height == 0 {
+		return fmt.Errorf("%w: %dx%d image", images.ErrTrimEmpty, imageRef.Width(), imageRef.Height())
 	}
 
 	// Extract the trimmed region
 	err = imageRef.ExtractArea(left, top, width, height)
 	if err != nil {
 		slog.ErrorContext(ctx, "Failed to extract trim area", slog.Any("err", err))
-		return nil, fmt.Errorf("failed to extract trim area: %w", err)
+		return fmt.Errorf("failed to extract trim area: %w", err)
+	}
+
+	return nil
+}
+
+// findBackgroundTrim finds the area left after removing borders matching background,
+// white when empty and the top-left pixel when auto
+func findBackgroundTrim(imageRef *vips.ImageRef, threshold float64, background string) (int, int, int, int, error) {
+	color := &vips.Color{R: 255, G: 255, B: 255}
+
+	switch background {
+	case "":
+	case "auto":
+		// Sample an 8 bit sRGB copy, FindTrim expects 0-255 values whatever the image depth
+		sample, err := imageRef.Copy()
+		if err != nil {
+			return 0, 0, 0, 0, err
+		}
+		defer sample.Close()
+
+		if err := sample.ToColorSpace(vips.InterpretationSRGB); err != nil {
+			return 0, 0, 0, 0, fmt.Errorf("failed to convert corner sample: %w", err)
+		}
+
+		pixel, err := sample.GetPoint(0, 0)
+		if err != nil {
+			return 0, 0, 0, 0, fmt.Errorf("failed to sample corner pixel: %w", err)
+		}
+		color = &vips.Color{R: uint8(pixel[0]), G: uint8(pixel[1]), B: uint8(pixel[2])}
+	default:
+		parsed, err := parseHexColor(background)
+		if err != nil {
+			return 0, 0, 0, 0, err
+		}
+		color = &vips.Color{R: parsed.R, G: parsed.G, B: parsed.B}
 	}
 
-	output, _, err := imageRef.ExportNative()
+	return imageRef.FindTrim(threshold, color)
+}
+
+// findAlphaTrim finds the area left after removing transparent borders,
+// an image without alpha has none so it is kept whole
+func findAlphaTrim(imageRef *vips.ImageRef, threshold float64) (int, int, int, int, error) {
+	if !imageRef.HasAlpha() {
+		return 0, 0, imageRef.Width(), imageRef.Height(), nil
+	}
+
+	alpha, err := imageRef.ExtractBandToImage(imageRef.Bands()-1, 1)
 	if err != nil {
-		slog.ErrorContext(ctx, "Failed to export image", slog.Any("err", err))
-		return nil, fmt.Errorf("failed to export image: %w", err)
+		return 0, 0, 0, 0, fmt.Errorf("failed to extract alpha channel: %w", err)
 	}
+	defer alpha.Close()
 
-	return output, nil
+	return alpha.FindTrim(threshold, &vips.Color{})
 }
 
 // VipsBlurTransformer implements blur transformation using VIPS
 type VipsBlurTransformer struct{}
 
-var _ ports.ImageTransformer = (*VipsBlurTransformer)(nil)
+var _ VipsImageTransformer = (*VipsBlurTransformer)(nil)
 
 func NewVipsBlurTransformer() *VipsBlurTransformer {
 	return &VipsBlurTransformer{}
@@ -233,41 +450,34 @@ func (t *VipsBlurTransformer) ValidateConfig(ctx context.Context, config map[str
 }
 
 func (t *VipsBlurTransformer) Transform(ctx context.Context, image []byte, config map[string]any) ([]byte, error) {
+	return transformBuffer(ctx, t, image, config)
+}
+
+func (t *VipsBlurTransformer) Apply(ctx context.Context, image *VipsImage, config map[string]any) error {
 	// Decode config
 	var cfg images.BlurConfig
 	if err := mapstructure.Decode(config, &cfg); err != nil {
-		return nil, fmt.Errorf("failed to decode blur config: %w", err)
+		return fmt.Errorf("failed to decode blur config: %w", err)
 	}
 
 	slog.DebugContext(ctx, "Applying blur transformation", slog.Float64("sigma", cfg.Sigma))
 
-	imageRef, err := vips.NewImageFromBuffer(image)
-	if err != nil {
-		slog.ErrorContext(ctx, "Failed to load image", slog.Any("err", err))
-		return nil, fmt.Errorf("failed to load image: %w", err)
-	}
-	defer imageRef.Close()
+	imageRef := image.Ref
 
 	// Apply Gaussian blur
-	err = imageRef.GaussianBlur(cfg.Sigma)
+	err := imageRef.GaussianBlur(cfg.Sigma)
 	if err != nil {
 		slog.ErrorContext(ctx, "Failed to apply blur", slog.Any("err", err))
-		return nil, fmt.Errorf("failed to apply blur: %w", err)
-	}
-
-	output, _, err := imageRef.ExportNative()
-	if err != nil {
-		slog.ErrorContext(ctx, "Failed to export image", slog.Any("err", err))
-		return nil, fmt.Errorf("failed to export image: %w", err)
+		return fmt.Errorf("failed to apply blur: %w", err)
 	}
 
-	return output, nil
+	return nil
 }
 
 // VipsRotateTransformer implements rotation transformation using VIPS
 type VipsRotateTransformer struct{}
 
-var _ ports.ImageTransformer = (*VipsRotateTransformer)(nil)
+var _ VipsImageTransformer = (*VipsRotateTransformer)(nil)
 
 func NewVipsRotateTransformer() *VipsRotateTransformer {
 	return &VipsRotateTransformer{}
@@ -289,24 +499,33 @@ func (t *VipsRotateTransformer) ValidateConfig(ctx context.Context, config map[s
 }
 
 func (t *VipsRotateTransformer) Transform(ctx context.Context, image []byte, config map[string]any) ([]byte, error) {
+	return transformBuffer(ctx, t, image, config)
+}
+
+func (t *VipsRotateTransformer) Apply(ctx context.Context, image *VipsImage, config map[string]any) error {
 	// Decode config
 	var cfg images.RotateConfig
 	if err := mapstructure.Decode(config, &cfg); err != nil {
-		return nil, fmt.Errorf("failed to decode rotate config: %w", err)
+		return fmt.Errorf("failed to decode rotate config: %w", err)
 	}
 
-	slog.DebugContext(ctx, "Applying rotate transformation", slog.Int("angle", cfg.Angle))
+	slog.DebugContext(ctx, "Applying rotate transformation",
+		slog.Float64("angle", cfg.Angle),
+		slog.String("background", cfg.Background))
 
-	imageRef, err := vips.NewImageFromBuffer(image)
-	if err != nil {
-		slog.ErrorContext(ctx, "Failed to load image", slog.Any("err", err))
-		return nil, fmt.Errorf("failed to load image: %w", err)
+	imageRef := image.Ref
+
+	// Normalize to [0, 360) so negative angles turn counter-clockwise
+	angle := math.Mod(cfg.Angle, 360)
+	if angle < 0 {
+		angle += 360
 	}
-	defer imageRef.Close()
 
-	// Convert angle to VIPS angle enum
+	// Right angles are rotated losslessly and keep the canvas tight
 	var vipsAngle vips.Angle
-	switch cfg.Angle {
+	switch angle {
+	case 0:
+		return nil
 	case 90:
 		vipsAngle = vips.Angle90
 	case 180:
@@ -314,21 +533,378 @@ func (t *VipsRotateTransformer) Transform(ctx context.Context, image []byte, con
 	case 270:
 		vipsAngle = vips.Angle270
 	default:
-		return nil, fmt.Errorf("unsupported rotation angle: %d", cfg.Angle)
+		background, err := backgroundColor(imageRef, cfg.Background)
+		if err != nil {
+			return fmt.Errorf("invalid background: %w", err)
+		}
+
+		err = imageRef.Similarity(1, angle, background, 0, 0, 0, 0)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to rotate image", slog.Any("err", err))
+			return fmt.Errorf("failed to rotate image: %w", err)
+		}
+
+		return nil
 	}
 
 	// Rotate the image
-	err = imageRef.Rotate(vipsAngle)
+	err := imageRef.Rotate(vipsAngle)
 	if err != nil {
 		slog.ErrorContext(ctx, "Failed to rotate image", slog.Any("err", err))
-		return nil, fmt.Errorf("failed to rotate image: %w", err)
+		return fmt.Errorf("failed to rotate image: %w", err)
 	}
 
-	output, _, err := imageRef.ExportNative()
+	return nil
+}
+
+// VipsFlipTransformer implements mirroring using VIPS, flip mirrors vertically and flop horizontally
+type VipsFlipTransformer struct {
+	name      string
+	direction vips.Direction
+}
+
+var _ VipsImageTransformer = (*VipsFlipTransformer)(nil)
+
+func NewVipsFlipTransformer() *VipsFlipTransformer {
+	return &VipsFlipTransformer{name: "flip", direction: vips.DirectionVertical}
+}
+
+func NewVipsFlopTransformer() *VipsFlipTransformer {
+	return &VipsFlipTransformer{name: "flop", direction: vips.DirectionHorizontal}
+}
+
+func (t *VipsFlipTransformer) Name() string {
+	return t.name
+}
+
+func (t *VipsFlipTransformer) ValidateConfig(ctx context.Context, config map[string]any) error {
+	// Mirroring has no required config, always valid
+	return nil
+}
+
+func (t *VipsFlipTransformer) Transform(ctx context.Context, image []byte, config map[string]any) ([]byte, error) {
+	return transformBuffer(ctx, t, image, config)
+}
+
+func (t *VipsFlipTransformer) Apply(ctx context.Context, image *VipsImage, config map[string]any) error {
+	slog.DebugContext(ctx, "Applying mirror transformation", slog.String("transformation", t.name))
+
+	err := image.Ref.Flip(t.direction)
 	if err != nil {
-		slog.ErrorContext(ctx, "Failed to export image", slog.Any("err", err))
-		return nil, fmt.Errorf("failed to export image: %w", err)
+		slog.ErrorContext(ctx, "Failed to mirror image", slog.Any("err", err))
+		return fmt.Errorf("failed to mirror image: %w", err)
+	}
+
+	return nil
+}
+
+// VipsAutoOrientTransformer applies the EXIF orientation to the pixels using VIPS
+type VipsAutoOrientTransformer struct{}
+
+var _ VipsImageTransformer = (*VipsAutoOrientTransformer)(nil)
+
+func NewVipsAutoOrientTransformer() *VipsAutoOrientTransformer {
+	return &VipsAutoOrientTransformer{}
+}
+
+func (t *VipsAutoOrientTransformer) Name() string {
+	return "auto_orient"
+}
+
+func (t *VipsAutoOrientTransformer) ValidateConfig(ctx context.Context, config map[string]any) error {
+	// Auto orientation has no required config, always valid
+	return nil
+}
+
+func (t *VipsAutoOrientTransformer) Transform(ctx context.Context, image []byte, config map[string]any) ([]byte, error) {
+	return transformBuffer(ctx, t, image, config)
+}
+
+func (t *VipsAutoOrientTransformer) Apply(ctx context.Context, image *VipsImage, config map[string]any) error {
+	imageRef := image.Ref
+	orientation := imageRef.Orientation()
+
+	slog.DebugContext(ctx, "Applying auto orient transformation", slog.Int("orientation", orientation))
+
+	// Each EXIF orientation is undone with the rotations and flips it stands for, rather than through
+	// govips's AutoRotate, so the orientation tag is cleared explicitly once the pixels are upright
+	var steps []func() error
+	switch orientation {
+	case 2:
+		steps = append(steps, func() error { return imageRef.Flip(vips.DirectionHorizontal) })
+	case 3:
+		steps = append(steps, func() error { return imageRef.Rotate(vips.Angle180) })
+	case 4:
+		steps = append(steps, func() error { return imageRef.Flip(vips.DirectionVertical) })
+	case 5:
+		steps = append(steps,
+			func() error { return imageRef.Rotate(vips.Angle90) },
+			func() error { return imageRef.Flip(vips.DirectionHorizontal) })
+	case 6:
+		steps = append(steps, func() error { return imageRef.Rotate(vips.Angle90) })
+	case 7:
+		steps = append(steps,
+			func() error { return imageRef.Rotate(vips.Angle270) },
+			func() error { return imageRef.Flip(vips.DirectionHorizontal) })
+	case 8:
+		steps = append(steps, func() error { return imageRef.Rotate(vips.Angle270) })
+	default:
+		// Already upright or no orientation tag at all
+		return nil
+	}
+
+	for _, step := range steps {
+		if err := step(); err != nil {
+			slog.ErrorContext(ctx, "Failed to orient image", slog.Any("err", err))
+			return fmt.Errorf("failed to orient image: %w", err)
+		}
+	}
+
+	// Viewers would otherwise rotate the now upright pixels a second time
+	if err := imageRef.RemoveOrientation(); err != nil {
+		slog.ErrorContext(ctx, "Failed to clear orientation", slog.Any("err", err))
+		return fmt.Errorf("failed to clear orientation: %w", err)
+	}
+
+	return nil
+}
+
+// VipsFormatTransformer implements output format conversion using VIPS
+type VipsFormatTransformer struct{}
+
+var _ VipsAnimationTransformer = (*VipsFormatTransformer)(nil)
+
+func NewVipsFormatTransformer() *VipsFormatTransformer {
+	return &VipsFormatTransformer{}
+}
+
+func (t *VipsFormatTransformer) Name() string {
+	return "format"
+}
+
+// AppliesToAllFrames lets the format step turn an animation into a still when the format has no animation
+func (t *VipsFormatTransformer) AppliesToAllFrames() {}
+
+func (t *VipsFormatTransformer) ValidateConfig(ctx context.Context, config map[string]any) error {
+	var cfg images.FormatConfig
+	if err := mapstructure.Decode(config, &cfg); err != nil {
+		return fmt.Errorf("failed to decode format config: %w", err)
+	}
+	if err := validate.Struct(cfg); err != nil {
+		return fmt.Errorf("invalid format config: %w", err)
+	}
+	return nil
+}
+
+func (t *VipsFormatTransformer) Transform(ctx context.Context, image []byte, config map[string]any) ([]byte, error) {
+	return transformBuffer(ctx, t, image, config)
+}
+
+// Apply does not touch the pixels, it only picks how the image is encoded once the pipeline is done
+func (t *VipsFormatTransformer) Apply(ctx context.Context, image *VipsImage, config map[string]any) error {
+	// Decode config
+	var cfg images.FormatConfig
+	if err := mapstructure.Decode(config, &cfg); err != nil {
+		return fmt.Errorf("failed to decode format config: %w", err)
+	}
+
+	slog.DebugContext(ctx, "Applying format transformation",
+		slog.String("format", cfg.Format),
+		slog.Int("quality", cfg.Quality),
+		slog.Bool("lossless", cfg.Lossless))
+
+	// Only GIF and WebP keep an animation, other formats get its first frame as a poster.
+	// Pages loaded from a page range are exported one by one, so they are all kept.
+	if cfg.Format != "gif" && cfg.Format != "webp" && !image.splitPages {
+		if err := image.KeepFrame(0); err != nil {
+			slog.ErrorContext(ctx, "Failed to extract poster frame", slog.Any("err", err))
+			return fmt.Errorf("failed to extract poster frame: %w", err)
+		}
+	}
+
+	// Only override the encoder defaults for the options that were given
+	switch cfg.Format {
+	case "jpeg":
+		params := vips.NewJpegExportParams()
+		params.StripMetadata = cfg.StripMetadata
+		params.Interlace = cfg.Interlace
+		if cfg.Quality > 0 {
+			params.Quality = cfg.Quality
+		}
+		image.encode = func(ref *vips.ImageRef) ([]byte, error) {
+			output, _, err := ref.ExportJpeg(params)
+			return output, err
+		}
+	case "png":
+		params := vips.NewPngExportParams()
+		params.StripMetadata = cfg.StripMetadata
+		params.Interlace = cfg.Interlace
+		if cfg.Quality > 0 {
+			params.Quality = cfg.Quality
+		}
+		image.encode = func(ref *vips.ImageRef) ([]byte, error) {
+			output, _, err := ref.ExportPng(params)
+			return output, err
+		}
+	case "webp":
+		params := vips.NewWebpExportParams()
+		params.StripMetadata = cfg.StripMetadata
+		params.Lossless = cfg.Lossless
+		if cfg.Quality > 0 {
+			params.Quality = cfg.Quality
+		}
+		image.encode = func(ref *vips.ImageRef) ([]byte, error) {
+			output, _, err := ref.ExportWebp(params)
+			return output, err
+		}
+	case "avif":
+		params := vips.NewAvifExportParams()
+		params.StripMetadata = cfg.StripMetadata
+		params.Lossless = cfg.Lossless
+		if cfg.Quality > 0 {
+			params.Quality = cfg.Quality
+		}
+		image.encode = func(ref *vips.ImageRef) ([]byte, error) {
+			output, _, err := ref.ExportAvif(params)
+			return output, err
+		}
+	case "gif":
+		params := vips.NewGifExportParams()
+		params.StripMetadata = cfg.StripMetadata
+		if cfg.Quality > 0 {
+			params.Quality = cfg.Quality
+		}
+		image.encode = func(ref *vips.ImageRef) ([]byte, error) {
+			output, _, err := ref.ExportGIF(params)
+			return output, err
+		}
+	case "tiff":
+		params := vips.NewTiffExportParams()
+		params.StripMetadata = cfg.StripMetadata
+		if cfg.Quality > 0 {
+			params.Quality = cfg.Quality
+		}
+		image.encode = func(ref *vips.ImageRef) ([]byte, error) {
+			output, _, err := ref.ExportTiff(params)
+			return output, err
+		}
+	default:
+		return fmt.Errorf("unsupported output format: %s", cfg.Format)
+	}
+
+	return nil
+}
+
+// VipsCropTransformer implements explicit, gravity and smart crops using VIPS
+type VipsCropTransformer struct{}
+
+var _ VipsImageTransformer = (*VipsCropTransformer)(nil)
+
+func NewVipsCropTransformer() *VipsCropTransformer {
+	return &VipsCropTransformer{}
+}
+
+func (t *VipsCropTransformer) Name() string {
+	return "crop"
+}
+
+func (t *VipsCropTransformer) ValidateConfig(ctx context.Context, config map[string]any) error {
+	var cfg images.CropConfig
+	if err := mapstructure.Decode(config, &cfg); err != nil {
+		return fmt.Errorf("failed to decode crop config: %w", err)
+	}
+	if err := validate.Struct(cfg); err != nil {
+		return fmt.Errorf("invalid crop config: %w", err)
+	}
+	return nil
+}
+
+func (t *VipsCropTransformer) Transform(ctx context.Context, image []byte, config map[string]any) ([]byte, error) {
+	return transformBuffer(ctx, t, image, config)
+}
+
+func (t *VipsCropTransformer) Apply(ctx context.Context, image *VipsImage, config map[string]any) error {
+	// Decode config
+	var cfg images.CropConfig
+	if err := mapstructure.Decode(config, &cfg); err != nil {
+		return fmt.Errorf("failed to decode crop config: %w", err)
+	}
+
+	slog.DebugContext(ctx, "Applying crop transformation",
+		slog.Int("left", cfg.Left),
+		slog.Int("top", cfg.Top),
+		slog.Int("width", cfg.Width),
+		slog.Int("height", cfg.Height),
+		slog.String("gravity", cfg.Gravity))
+
+	imageRef := image.Ref
+
+	imageWidth := imageRef.Width()
+	imageHeight := imageRef.Height()
+
+	// Only known once the image is decoded, so this can't be part of ValidateConfig
+	if cfg.Gravity == "" {
+		if cfg.Left+cfg.Width > imageWidth || cfg.Top+cfg.Height > imageHeight {
+			return fmt.Errorf("%w: %dx%d+%d+%d does not fit in %dx%d", images.ErrCropOutOfBounds,
+				cfg.Width, cfg.Height, cfg.Left, cfg.Top, imageWidth, imageHeight)
+		}
+
+		err := imageRef.ExtractArea(cfg.Left, cfg.Top, cfg.Width, cfg.Height)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to extract crop area", slog.Any("err", err))
+			return fmt.Errorf("failed to extract crop area: %w", err)
+		}
+
+		return nil
+	}
+
+	width := min(cfg.Width, imageWidth)
+	height := min(cfg.Height, imageHeight)
+
+	switch cfg.Gravity {
+	case "attention", "entropy":
+		interesting := vips.InterestingAttention
+		if cfg.Gravity == "entropy" {
+			interesting = vips.InterestingEntropy
+		}
+
+		err := imageRef.SmartCrop(width, height, interesting)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to smart crop image", slog.Any("err", err))
+			return fmt.Errorf("failed to smart crop image: %w", err)
+		}
+
+		return nil
+	}
+
+	left, top := gravityOffset(cfg.Gravity, imageWidth-width, imageHeight-height)
+
+	err := imageRef.ExtractArea(left, top, width, height)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to extract crop area", slog.Any("err", err))
+		return fmt.Errorf("failed to extract crop area: %w", err)
+	}
+
+	return nil
+}
+
+// gravityOffset returns where an area leaving spareX x spareY pixels uncovered is placed for a gravity
+func gravityOffset(gravity string, spareX, spareY int) (int, int) {
+	left, top := spareX/2, spareY/2
+
+	switch gravity {
+	case "north", "north-east", "north-west":
+		top = 0
+	case "south", "south-east", "south-west":
+		top = spareY
+	}
+
+	switch gravity {
+	case "west", "north-west", "south-west":
+		left = 0
+	case "east", "north-east", "south-east":
+		left = spareX
 	}
 
-	return output, nil
+	return left, top
 }
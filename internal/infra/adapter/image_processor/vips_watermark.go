@@ -0,0 +1,319 @@
+package image
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"strings"
+
+	"github.com/davidbyttow/govips/v2/vips"
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/taldoflemis/sora-henkan/internal/core/domain/images"
+	"github.com/taldoflemis/sora-henkan/internal/core/ports"
+)
+
+func init() {
+	registerVipsTransformer(func(deps TransformerDependencies) ports.TransformerRegistration {
+		return ports.TransformerRegistration{
+			// The watermark is read from the same bucket the images live in
+			Transformer:  NewVipsWatermarkTransformer(deps.ObjectStorer, deps.ImageRepository, deps.BucketName),
+			Aliases:      []string{"overlay"},
+			RenderArgs:   []string{"image_id", "gravity"},
+			Description:  "Composites another stored image onto the image",
+			ConfigSchema: configSchema(images.WatermarkConfig{Blend: "over"}),
+			Examples: []map[string]any{
+				{"image_id": "3fa85f64-5717-4562-b3fc-2c963f66afa6", "gravity": "south-east", "margin": 16, "opacity": 0.5},
+				{"storage_key": "watermarks/logo.png", "scale": 0.2, "tile": true},
+			},
+		}
+	})
+}
+
+// VipsWatermarkTransformer composites another stored image onto the image using VIPS
+type VipsWatermarkTransformer struct {
+	objectStorer    ports.ObjectStorer
+	imageRepository ports.ImageRepository
+	bucket          string
+}
+
+var _ VipsFramePreparer = (*VipsWatermarkTransformer)(nil)
+
+func NewVipsWatermarkTransformer(
+	objectStorer ports.ObjectStorer,
+	imageRepository ports.ImageRepository,
+	bucket string,
+) *VipsWatermarkTransformer {
+	return &VipsWatermarkTransformer{
+		objectStorer:    objectStorer,
+		imageRepository: imageRepository,
+		bucket:          bucket,
+	}
+}
+
+func (t *VipsWatermarkTransformer) Name() string {
+	return "watermark"
+}
+
+func (t *VipsWatermarkTransformer) ValidateConfig(ctx context.Context, config map[string]any) error {
+	var cfg images.WatermarkConfig
+	if err := mapstructure.Decode(config, &cfg); err != nil {
+		return fmt.Errorf("failed to decode watermark config: %w", err)
+	}
+	if err := validate.Struct(cfg); err != nil {
+		return fmt.Errorf("invalid watermark config: %w", err)
+	}
+	return nil
+}
+
+func (t *VipsWatermarkTransformer) Transform(ctx context.Context, image []byte, config map[string]any) ([]byte, error) {
+	return transformBuffer(ctx, t, image, config)
+}
+
+func (t *VipsWatermarkTransformer) Apply(ctx context.Context, image *VipsImage, config map[string]any) error {
+	step, err := t.Prepare(ctx, image, config)
+	if err != nil {
+		return err
+	}
+	defer step.Close()
+
+	return step.ApplyToFrame(ctx, image)
+}
+
+// Prepare loads the overlay once and fits it to the frame size, so animations don't load it for every frame
+func (t *VipsWatermarkTransformer) Prepare(ctx context.Context, frame *VipsImage, config map[string]any) (VipsPreparedStep, error) {
+	// Decode config
+	var cfg images.WatermarkConfig
+	if err := mapstructure.Decode(config, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode watermark config: %w", err)
+	}
+
+	slog.DebugContext(ctx, "Applying watermark transformation",
+		slog.String("image_id", cfg.ImageID),
+		slog.String("storage_key", cfg.StorageKey),
+		slog.String("gravity", cfg.Gravity),
+		slog.Float64("opacity", cfg.Opacity),
+		slog.Float64("scale", cfg.Scale),
+		slog.Bool("tile", cfg.Tile))
+
+	overlay, err := t.loadOverlay(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	step, err := fitOverlay(ctx, overlay, cfg, frame.Ref.Width(), frame.Ref.Height())
+	if err != nil {
+		overlay.Close()
+		return nil, err
+	}
+
+	return step, nil
+}
+
+// fitOverlay scales, fades and places the overlay for frames of width x height
+func fitOverlay(ctx context.Context, overlay *vips.ImageRef, cfg images.WatermarkConfig, width, height int) (*preparedOverlay, error) {
+	if cfg.Scale > 0 {
+		factor := cfg.Scale * float64(width) / float64(overlay.Width())
+		if err := overlay.Resize(factor, vips.KernelLanczos3); err != nil {
+			slog.ErrorContext(ctx, "Failed to scale watermark", slog.Any("err", err))
+			return nil, fmt.Errorf("failed to scale watermark: %w", err)
+		}
+	}
+
+	// Opacity is applied by scaling the alpha band, so the overlay needs one
+	if !overlay.HasAlpha() {
+		if err := overlay.AddAlpha(); err != nil {
+			return nil, fmt.Errorf("failed to add alpha channel to watermark: %w", err)
+		}
+	}
+	if cfg.Opacity > 0 && cfg.Opacity < 1 {
+		multipliers := make([]float64, overlay.Bands())
+		offsets := make([]float64, overlay.Bands())
+		for i := range multipliers {
+			multipliers[i] = 1
+		}
+		multipliers[len(multipliers)-1] = cfg.Opacity
+
+		if err := overlay.Linear(multipliers, offsets); err != nil {
+			slog.ErrorContext(ctx, "Failed to apply watermark opacity", slog.Any("err", err))
+			return nil, fmt.Errorf("failed to apply watermark opacity: %w", err)
+		}
+	}
+
+	left, top := cfg.Left, cfg.Top
+	if cfg.Gravity != "" {
+		left, top = watermarkPosition(cfg.Gravity, cfg.Margin,
+			width-overlay.Width(), height-overlay.Height())
+	}
+
+	if cfg.Tile {
+		// Repeat the overlay from its placement, then cut the grid back to the image
+		startLeft := left % overlay.Width()
+		if startLeft > 0 {
+			startLeft -= overlay.Width()
+		}
+		startTop := top % overlay.Height()
+		if startTop > 0 {
+			startTop -= overlay.Height()
+		}
+
+		across := int(math.Ceil(float64(width-startLeft) / float64(overlay.Width())))
+		down := int(math.Ceil(float64(height-startTop) / float64(overlay.Height())))
+
+		if err := overlay.Replicate(across, down); err != nil {
+			slog.ErrorContext(ctx, "Failed to tile watermark", slog.Any("err", err))
+			return nil, fmt.Errorf("failed to tile watermark: %w", err)
+		}
+
+		err := overlay.ExtractArea(-startLeft, -startTop, width, height)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to tile watermark", slog.Any("err", err))
+			return nil, fmt.Errorf("failed to tile watermark: %w", err)
+		}
+
+		left, top = 0, 0
+	}
+
+	return &preparedOverlay{what: "watermark", overlay: overlay, blend: blendMode(cfg.Blend), left: left, top: top}, nil
+}
+
+// preparedOverlay is an overlay placed for a frame size, ready to be composited onto frames of that size
+type preparedOverlay struct {
+	// what names the overlay in errors
+	what      string
+	overlay   *vips.ImageRef
+	blend     vips.BlendMode
+	left, top int
+}
+
+var _ VipsPreparedStep = (*preparedOverlay)(nil)
+
+func (w *preparedOverlay) ApplyToFrame(ctx context.Context, frame *VipsImage) error {
+	imageRef := frame.Ref
+	hadAlpha := imageRef.HasAlpha()
+
+	if err := imageRef.Composite(w.overlay, w.blend, w.left, w.top); err != nil {
+		slog.ErrorContext(ctx, "Failed to composite "+w.what, slog.Any("err", err))
+		return fmt.Errorf("failed to composite %s: %w", w.what, err)
+	}
+
+	// Compositing adds an alpha band, which opaque images are better off without
+	if !hadAlpha {
+		if err := imageRef.Flatten(&vips.Color{}); err != nil {
+			slog.ErrorContext(ctx, "Failed to flatten image with "+w.what, slog.Any("err", err))
+			return fmt.Errorf("failed to flatten image with %s: %w", w.what, err)
+		}
+	}
+
+	return nil
+}
+
+func (w *preparedOverlay) Close() {
+	w.overlay.Close()
+}
+
+// loadOverlay decodes the watermark image from the bucket
+func (t *VipsWatermarkTransformer) loadOverlay(ctx context.Context, cfg images.WatermarkConfig) (*vips.ImageRef, error) {
+	key := cfg.StorageKey
+
+	// ValidateConfig already checks this, it is checked again since the key is read straight from the bucket
+	if cfg.ImageID == "" && (!strings.HasPrefix(key, images.WatermarkKeyPrefix) || strings.Contains(key, "..")) {
+		return nil, fmt.Errorf("watermark storage key %q is outside of %s", key, images.WatermarkKeyPrefix)
+	}
+
+	if cfg.ImageID != "" {
+		watermarkImage, err := t.imageRepository.FindImageByID(ctx, cfg.ImageID)
+		if err != nil {
+			if errors.Is(err, ports.ErrImageNotFound) {
+				return nil, fmt.Errorf("%w: image %s", images.ErrWatermarkNotFound, cfg.ImageID)
+			}
+			return nil, fmt.Errorf("failed to find watermark image: %w", err)
+		}
+
+		key = watermarkImage.TransformedImageKey
+		if key == "" {
+			key = watermarkImage.ObjectStorageImageKey
+		}
+	}
+
+	if _, err := t.objectStorer.Stat(ctx, key, t.bucket); err != nil {
+		if errors.Is(err, ports.ErrObjectNotFound) {
+			return nil, fmt.Errorf("%w: key %q", images.ErrWatermarkNotFound, key)
+		}
+		return nil, fmt.Errorf("failed to stat watermark image: %w", err)
+	}
+
+	body, err := t.objectStorer.Get(ctx, key, t.bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watermark image: %w", err)
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read watermark image: %w", err)
+	}
+
+	overlay, err := vips.NewImageFromBuffer(data)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to load watermark image", slog.Any("err", err))
+		return nil, fmt.Errorf("failed to load watermark image: %w", err)
+	}
+
+	return overlay, nil
+}
+
+// watermarkPosition anchors the overlay to a gravity, margin pixels in from the edges it touches
+func watermarkPosition(gravity string, margin, spareX, spareY int) (int, int) {
+	left, top := gravityOffset(gravity, spareX, spareY)
+
+	switch gravity {
+	case "north", "north-east", "north-west":
+		top += margin
+	case "south", "south-east", "south-west":
+		top -= margin
+	}
+
+	switch gravity {
+	case "west", "north-west", "south-west":
+		left += margin
+	case "east", "north-east", "south-east":
+		left -= margin
+	}
+
+	return left, top
+}
+
+// blendMode maps a blend name to its VIPS blend mode, over being the default
+func blendMode(name string) vips.BlendMode {
+	switch name {
+	case "multiply":
+		return vips.BlendModeMultiply
+	case "screen":
+		return vips.BlendModeScreen
+	case "overlay":
+		return vips.BlendModeOverlay
+	case "darken":
+		return vips.BlendModeDarken
+	case "lighten":
+		return vips.BlendModeLighten
+	case "colour-dodge":
+		return vips.BlendModeColorDodge
+	case "colour-burn":
+		return vips.BlendModeColorBurn
+	case "hard-light":
+		return vips.BlendModeHardLight
+	case "soft-light":
+		return vips.BlendModeSoftLight
+	case "difference":
+		return vips.BlendModeDifference
+	case "exclusion":
+		return vips.BlendModeExclusion
+	case "add":
+		return vips.BlendModeAdd
+	default:
+		return vips.BlendModeOver
+	}
+}
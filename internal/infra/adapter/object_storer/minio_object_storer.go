@@ -50,7 +50,7 @@ func (m *MinioObjectStorer) Delete(ctx context.Context, key string, bucket strin
 }
 
 // Get implements ports.ObjectStorer.
-func (m *MinioObjectStorer) Get(ctx context.Context, key string, bucket string) (io.ReadCloser, error) {
+func (m *MinioObjectStorer) Get(ctx context.Context, key string, bucket string) (io.ReadSeekCloser, error) {
 	ctx, span := tracer.Start(ctx, "MinioObjectStorer.Get", trace.WithAttributes(
 		attribute.String("object.key", key),
 		attribute.String("object.bucket", bucket),
@@ -104,31 +104,131 @@ func (m *MinioObjectStorer) ListKeys(ctx context.Context) ([]string, error) {
 }
 
 // PresignedUpload implements ports.ObjectStorer.
-func (m *MinioObjectStorer) PresignedUpload(ctx context.Context, bucket string) (string, error) {
+func (m *MinioObjectStorer) PresignedUpload(ctx context.Context, key string, bucket string, expiry time.Duration) (string, error) {
 	ctx, span := tracer.Start(ctx, "MinioObjectStorer.PresignedUpload", trace.WithAttributes(
+		attribute.String("object.key", key),
 		attribute.String("object.bucket", bucket),
 	))
 	defer span.End()
 
-	// Generate a unique key for the upload
-	// Note: The interface might need to be updated to accept a key parameter
-	// For now, generating a placeholder key
-	key := "upload-placeholder"
-
-	// Generate presigned URL valid for 15 minutes
-	presignedURL, err := m.minioClient.PresignedPutObject(ctx, bucket, key, 15*time.Minute)
+	presignedURL, err := m.minioClient.PresignedPutObject(ctx, bucket, key, expiry)
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to generate presigned upload URL", slog.Any("err", err))
 		telemetry.RegisterSpanError(span, err)
 		return "", err
 	}
 
-	span.SetAttributes(attribute.String("presigned.url", presignedURL.String()))
-	slog.InfoContext(ctx, "successfully generated presigned upload URL", slog.String("url", presignedURL.String()))
+	slog.InfoContext(ctx, "successfully generated presigned upload URL", slog.String("key", key))
 
 	return presignedURL.String(), nil
 }
 
+// DeletePrefix implements ports.ObjectStorer.
+func (m *MinioObjectStorer) DeletePrefix(ctx context.Context, prefix string, bucket string) error {
+	ctx, span := tracer.Start(ctx, "MinioObjectStorer.DeletePrefix", trace.WithAttributes(
+		attribute.String("object.prefix", prefix),
+		attribute.String("object.bucket", bucket),
+	))
+	defer span.End()
+
+	objectCh := m.minioClient.ListObjects(ctx, bucket, minio.ListObjectsOptions{
+		Prefix:    prefix,
+		Recursive: true,
+	})
+
+	// Drain every error so the removal goroutine can finish, but report the first one
+	var err error
+	for removeErr := range m.minioClient.RemoveObjects(ctx, bucket, objectCh, minio.RemoveObjectsOptions{}) {
+		slog.ErrorContext(ctx, "failed to delete object", slog.String("key", removeErr.ObjectName), slog.Any("err", removeErr.Err))
+		if err == nil {
+			err = removeErr.Err
+		}
+	}
+
+	if err != nil {
+		telemetry.RegisterSpanError(span, err)
+		return err
+	}
+
+	slog.InfoContext(ctx, "successfully deleted objects", slog.String("prefix", prefix))
+
+	return nil
+}
+
+// GetRange implements ports.ObjectStorer.
+func (m *MinioObjectStorer) GetRange(ctx context.Context, key string, bucket string, offset int64, length int64) (io.ReadCloser, error) {
+	ctx, span := tracer.Start(ctx, "MinioObjectStorer.GetRange", trace.WithAttributes(
+		attribute.String("object.key", key),
+		attribute.String("object.bucket", bucket),
+		attribute.Int64("object.offset", offset),
+		attribute.Int64("object.length", length),
+	))
+	defer span.End()
+
+	opts := minio.GetObjectOptions{}
+	if err := opts.SetRange(offset, offset+length-1); err != nil {
+		slog.ErrorContext(ctx, "invalid object range", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	object, err := m.minioClient.GetObject(ctx, bucket, key, opts)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get object range", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	return object, nil
+}
+
+// PresignedDownload implements ports.ObjectStorer.
+func (m *MinioObjectStorer) PresignedDownload(ctx context.Context, key string, bucket string, expiry time.Duration) (string, error) {
+	ctx, span := tracer.Start(ctx, "MinioObjectStorer.PresignedDownload", trace.WithAttributes(
+		attribute.String("object.key", key),
+		attribute.String("object.bucket", bucket),
+	))
+	defer span.End()
+
+	presignedURL, err := m.minioClient.PresignedGetObject(ctx, bucket, key, expiry, nil)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to generate presigned download URL", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return "", err
+	}
+
+	return presignedURL.String(), nil
+}
+
+// Stat implements ports.ObjectStorer.
+func (m *MinioObjectStorer) Stat(ctx context.Context, key string, bucket string) (*ports.ObjectInfo, error) {
+	ctx, span := tracer.Start(ctx, "MinioObjectStorer.Stat", trace.WithAttributes(
+		attribute.String("object.key", key),
+		attribute.String("object.bucket", bucket),
+	))
+	defer span.End()
+
+	// Ask for the checksum the uploader may have sent along with the object
+	info, err := m.minioClient.StatObject(ctx, bucket, key, minio.StatObjectOptions{Checksum: true})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == minio.NoSuchKey {
+			return nil, ports.ErrObjectNotFound
+		}
+		slog.ErrorContext(ctx, "failed to stat object", slog.Any("err", err))
+		telemetry.RegisterSpanError(span, err)
+		return nil, err
+	}
+
+	return &ports.ObjectInfo{
+		Key:            info.Key,
+		Size:           info.Size,
+		ContentType:    info.ContentType,
+		ETag:           info.ETag,
+		LastModified:   info.LastModified,
+		ChecksumCRC32C: info.ChecksumCRC32C,
+	}, nil
+}
+
 // Store implements ports.ObjectStorer.
 func (m *MinioObjectStorer) Store(ctx context.Context, key string, bucket string, mineType string, obj io.Reader) error {
 	ctx, span := tracer.Start(ctx, "MinioObjectStorer.Store", trace.WithAttributes(
@@ -20,17 +20,74 @@ var tracer = otel.Tracer("")
 
 // metadataModel represents the DynamoDB persistence model for image metadata
 type metadataModel struct {
-	ID                    string `dynamodbav:"id"`
-	OriginalImageURL      string `dynamodbav:"original_image_url"`
-	ObjectStorageImageKey string `dynamodbav:"object_storage_image_key"`
-	TransformedImageKey   string `dynamodbav:"transformed_image_key"`
-	MimeType              string `dynamodbav:"mime_type"`
-	Status                string `dynamodbav:"status"`
-	Checksum              string `dynamodbav:"checksum"`
-	ErrorMessage          string `dynamodbav:"error_message"`
-	TransformationCount   int    `dynamodbav:"transformation_count"`
-	UpdatedAt             string `dynamodbav:"updated_at"`
-	CreatedAt             string `dynamodbav:"created_at"`
+	ID                    string           `dynamodbav:"id"`
+	OriginalImageURL      string           `dynamodbav:"original_image_url"`
+	ObjectStorageImageKey string           `dynamodbav:"object_storage_image_key"`
+	TransformedImageKey   string           `dynamodbav:"transformed_image_key"`
+	MimeType              string           `dynamodbav:"mime_type"`
+	TransformedMimeType   string           `dynamodbav:"transformed_mime_type"`
+	Status                string           `dynamodbav:"status"`
+	Checksum              string           `dynamodbav:"checksum"`
+	ErrorMessage          string           `dynamodbav:"error_message"`
+	TransformationCount   int              `dynamodbav:"transformation_count"`
+	OriginalProperties    *propertiesModel `dynamodbav:"original_properties"`
+	TransformedProperties *propertiesModel `dynamodbav:"transformed_properties"`
+	UpdatedAt             string           `dynamodbav:"updated_at"`
+	CreatedAt             string           `dynamodbav:"created_at"`
+}
+
+// propertiesModel represents the DynamoDB persistence model for the properties of a stored image
+type propertiesModel struct {
+	Width       int               `dynamodbav:"width"`
+	Height      int               `dynamodbav:"height"`
+	Size        int64             `dynamodbav:"size"`
+	ColorSpace  string            `dynamodbav:"color_space"`
+	BitDepth    int               `dynamodbav:"bit_depth"`
+	HasAlpha    bool              `dynamodbav:"has_alpha"`
+	Pages       int               `dynamodbav:"pages"`
+	Orientation int               `dynamodbav:"orientation"`
+	ICCProfile  string            `dynamodbav:"icc_profile"`
+	EXIF        map[string]string `dynamodbav:"exif,omitempty"`
+}
+
+// toDomain converts a persistence model to domain model
+func (m *propertiesModel) toDomain() *images.ImageProperties {
+	if m == nil {
+		return nil
+	}
+
+	return &images.ImageProperties{
+		Width:       m.Width,
+		Height:      m.Height,
+		Size:        m.Size,
+		ColorSpace:  m.ColorSpace,
+		BitDepth:    m.BitDepth,
+		HasAlpha:    m.HasAlpha,
+		Pages:       m.Pages,
+		Orientation: m.Orientation,
+		ICCProfile:  m.ICCProfile,
+		EXIF:        m.EXIF,
+	}
+}
+
+// fromPropertiesDomain converts a domain model to persistence model
+func fromPropertiesDomain(properties *images.ImageProperties) *propertiesModel {
+	if properties == nil {
+		return nil
+	}
+
+	return &propertiesModel{
+		Width:       properties.Width,
+		Height:      properties.Height,
+		Size:        properties.Size,
+		ColorSpace:  properties.ColorSpace,
+		BitDepth:    properties.BitDepth,
+		HasAlpha:    properties.HasAlpha,
+		Pages:       properties.Pages,
+		Orientation: properties.Orientation,
+		ICCProfile:  properties.ICCProfile,
+		EXIF:        properties.EXIF,
+	}
 }
 
 // toDomain converts a persistence model to domain model
@@ -51,10 +108,13 @@ func (m *metadataModel) toDomain() (*images.ImageMetadata, error) {
 		ObjectStorageImageKey: m.ObjectStorageImageKey,
 		TransformedImageKey:   m.TransformedImageKey,
 		MimeType:              m.MimeType,
+		TransformedMimeType:   m.TransformedMimeType,
 		Status:                m.Status,
 		Checksum:              m.Checksum,
 		ErrorMessage:          m.ErrorMessage,
 		TransformationCount:   m.TransformationCount,
+		OriginalProperties:    m.OriginalProperties.toDomain(),
+		TransformedProperties: m.TransformedProperties.toDomain(),
 		UpdatedAt:             updatedAt,
 		CreatedAt:             createdAt,
 	}, nil
@@ -68,10 +128,13 @@ func fromMetadataDomain(meta *images.ImageMetadata) *metadataModel {
 		ObjectStorageImageKey: meta.ObjectStorageImageKey,
 		TransformedImageKey:   meta.TransformedImageKey,
 		MimeType:              meta.MimeType,
+		TransformedMimeType:   meta.TransformedMimeType,
 		Status:                meta.Status,
 		Checksum:              meta.Checksum,
 		ErrorMessage:          meta.ErrorMessage,
 		TransformationCount:   meta.TransformationCount,
+		OriginalProperties:    fromPropertiesDomain(meta.OriginalProperties),
+		TransformedProperties: fromPropertiesDomain(meta.TransformedProperties),
 		UpdatedAt:             meta.UpdatedAt.Format(time.RFC3339),
 		CreatedAt:             meta.CreatedAt.Format(time.RFC3339),
 	}
@@ -127,7 +190,20 @@ func (d *DynamoDBImageMetadataRepository) UpdateMetadata(ctx context.Context, im
 	metadata := image.ToMetadata()
 	updatedAt := time.Now().Format(time.RFC3339)
 
-	_, err := d.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+	// Properties are absent until the image is processed, which marshals to a NULL attribute
+	originalProperties, err := attributevalue.Marshal(fromPropertiesDomain(metadata.OriginalProperties))
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to marshal original properties: %w", err)
+	}
+
+	transformedProperties, err := attributevalue.Marshal(fromPropertiesDomain(metadata.TransformedProperties))
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to marshal transformed properties: %w", err)
+	}
+
+	_, err = d.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
 		TableName: aws.String(d.tableName),
 		Key: map[string]types.AttributeValue{
 			"id": &types.AttributeValueMemberS{Value: metadata.ID},
@@ -137,24 +213,30 @@ func (d *DynamoDBImageMetadataRepository) UpdateMetadata(ctx context.Context, im
 			object_storage_image_key = :storage_key,
 			transformed_image_key = :transformed_key,
 			mime_type = :mime,
+			transformed_mime_type = :transformed_mime,
 			#status = :status,
 			checksum = :checksum,
 			error_message = :error_msg,
 			transformation_count = :trans_count,
+			original_properties = :original_props,
+			transformed_properties = :transformed_props,
 			updated_at = :updated_at`),
 		ExpressionAttributeNames: map[string]string{
 			"#status": "status", // status is a reserved word in DynamoDB
 		},
 		ExpressionAttributeValues: map[string]types.AttributeValue{
-			":original_url":    &types.AttributeValueMemberS{Value: metadata.OriginalImageURL},
-			":storage_key":     &types.AttributeValueMemberS{Value: metadata.ObjectStorageImageKey},
-			":transformed_key": &types.AttributeValueMemberS{Value: metadata.TransformedImageKey},
-			":mime":            &types.AttributeValueMemberS{Value: metadata.MimeType},
-			":status":          &types.AttributeValueMemberS{Value: metadata.Status},
-			":checksum":        &types.AttributeValueMemberS{Value: metadata.Checksum},
-			":error_msg":       &types.AttributeValueMemberS{Value: metadata.ErrorMessage},
-			":trans_count":     &types.AttributeValueMemberN{Value: strconv.Itoa(metadata.TransformationCount)},
-			":updated_at":      &types.AttributeValueMemberS{Value: updatedAt},
+			":original_url":      &types.AttributeValueMemberS{Value: metadata.OriginalImageURL},
+			":storage_key":       &types.AttributeValueMemberS{Value: metadata.ObjectStorageImageKey},
+			":transformed_key":   &types.AttributeValueMemberS{Value: metadata.TransformedImageKey},
+			":mime":              &types.AttributeValueMemberS{Value: metadata.MimeType},
+			":transformed_mime":  &types.AttributeValueMemberS{Value: metadata.TransformedMimeType},
+			":status":            &types.AttributeValueMemberS{Value: metadata.Status},
+			":checksum":          &types.AttributeValueMemberS{Value: metadata.Checksum},
+			":error_msg":         &types.AttributeValueMemberS{Value: metadata.ErrorMessage},
+			":trans_count":       &types.AttributeValueMemberN{Value: strconv.Itoa(metadata.TransformationCount)},
+			":original_props":    originalProperties,
+			":transformed_props": transformedProperties,
+			":updated_at":        &types.AttributeValueMemberS{Value: updatedAt},
 		},
 	})
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/bits"
 	"time"
 
 	"github.com/google/uuid"
@@ -26,9 +27,18 @@ type imageModel struct {
 	MimeType              string          `db:"mime_type"`
 	Status                string          `db:"status"`
 	TransformedImageKey   string          `db:"transformed_image_key"`
+	TransformedMimeType   string          `db:"transformed_mime_type"`
+	PageCount             int             `db:"page_count"`
 	Checksum              string          `db:"checksum"`
+	SHA256                string          `db:"sha256"`
+	PerceptualHash        *int64          `db:"perceptual_hash"`
 	ErrorMessage          string          `db:"error_message"`
 	Transformations       json.RawMessage `db:"transformations"`
+	BlurHash              string          `db:"blurhash"`
+	DominantColor         string          `db:"dominant_color"`
+	Palette               []string        `db:"palette"`
+	OriginalProperties    json.RawMessage `db:"original_properties"`
+	TransformedProperties json.RawMessage `db:"transformed_properties"`
 	UpdatedAt             time.Time       `db:"updated_at"`
 	CreatedAt             time.Time       `db:"created_at"`
 }
@@ -43,6 +53,22 @@ func (m *imageModel) toDomain() (*images.Image, error) {
 		}
 	}
 
+	originalProperties, err := unmarshalProperties(m.OriginalProperties)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal original properties: %w", err)
+	}
+
+	transformedProperties, err := unmarshalProperties(m.TransformedProperties)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal transformed properties: %w", err)
+	}
+
+	// The hash is unsigned, Postgres only has signed 64 bit integers
+	var perceptualHash string
+	if m.PerceptualHash != nil {
+		perceptualHash = images.FormatPerceptualHash(uint64(*m.PerceptualHash))
+	}
+
 	return &images.Image{
 		ID:                    m.ID,
 		OriginalImageURL:      m.OriginalImageURL,
@@ -50,9 +76,18 @@ func (m *imageModel) toDomain() (*images.Image, error) {
 		MimeType:              m.MimeType,
 		Status:                m.Status,
 		TransformedImageKey:   m.TransformedImageKey,
+		TransformedMimeType:   m.TransformedMimeType,
+		PageCount:             m.PageCount,
 		Checksum:              m.Checksum,
+		SHA256:                m.SHA256,
+		PerceptualHash:        perceptualHash,
 		ErrorMessage:          m.ErrorMessage,
 		Transformations:       transformations,
+		BlurHash:              m.BlurHash,
+		DominantColor:         m.DominantColor,
+		Palette:               m.Palette,
+		OriginalProperties:    originalProperties,
+		TransformedProperties: transformedProperties,
 		UpdatedAt:             m.UpdatedAt,
 		CreatedAt:             m.CreatedAt,
 	}, nil
@@ -65,6 +100,26 @@ func fromDomain(img *images.Image) (*imageModel, error) {
 		return nil, fmt.Errorf("failed to marshal transformations: %w", err)
 	}
 
+	originalPropertiesJSON, err := json.Marshal(img.OriginalProperties)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal original properties: %w", err)
+	}
+
+	transformedPropertiesJSON, err := json.Marshal(img.TransformedProperties)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal transformed properties: %w", err)
+	}
+
+	var perceptualHash *int64
+	if img.PerceptualHash != "" {
+		hash, err := images.ParsePerceptualHash(img.PerceptualHash)
+		if err != nil {
+			return nil, err
+		}
+		signedHash := int64(hash)
+		perceptualHash = &signedHash
+	}
+
 	return &imageModel{
 		ID:                    img.ID,
 		OriginalImageURL:      img.OriginalImageURL,
@@ -72,14 +127,54 @@ func fromDomain(img *images.Image) (*imageModel, error) {
 		MimeType:              img.MimeType,
 		Status:                img.Status,
 		TransformedImageKey:   img.TransformedImageKey,
+		TransformedMimeType:   img.TransformedMimeType,
+		PageCount:             img.PageCount,
 		Checksum:              img.Checksum,
+		SHA256:                img.SHA256,
+		PerceptualHash:        perceptualHash,
 		ErrorMessage:          img.ErrorMessage,
 		Transformations:       transformationsJSON,
+		BlurHash:              img.BlurHash,
+		DominantColor:         img.DominantColor,
+		Palette:               img.Palette,
+		OriginalProperties:    originalPropertiesJSON,
+		TransformedProperties: transformedPropertiesJSON,
 		UpdatedAt:             img.UpdatedAt,
 		CreatedAt:             img.CreatedAt,
 	}, nil
 }
 
+// unmarshalProperties reads image properties, which are null until the image is processed
+func unmarshalProperties(data json.RawMessage) (*images.ImageProperties, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	var properties images.ImageProperties
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return nil, err
+	}
+	return &properties, nil
+}
+
+// perceptualHashBands is the number of 16 bit bands the perceptual_hash column is indexed by
+const perceptualHashBands = 4
+
+// bandCandidates returns, for each band of hash from the most significant one, every band value
+// at most radius bits away from it
+func bandCandidates(hash uint64, radius int) [perceptualHashBands][]int32 {
+	var candidates [perceptualHashBands][]int32
+	for band := range perceptualHashBands {
+		value := uint16(hash >> (48 - 16*band))
+		for mask := range 1 << 16 {
+			if bits.OnesCount16(uint16(mask)) <= radius {
+				candidates[band] = append(candidates[band], int32(value^uint16(mask)))
+			}
+		}
+	}
+	return candidates
+}
+
 type PostgresImageRepository struct {
 	pool *pgxpool.Pool
 }
@@ -106,9 +201,10 @@ func (p *PostgresImageRepository) CreateNewImage(ctx context.Context, image *ima
 	query := `
 		INSERT INTO images (
 			id, original_image_url, object_storage_image_key, mime_type, status,
-			transformed_image_key, checksum, error_message, transformations, updated_at, created_at
+			transformed_image_key, transformed_mime_type, page_count, checksum, sha256, perceptual_hash, error_message, transformations,
+			blurhash, dominant_color, palette, original_properties, transformed_properties, updated_at, created_at
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20
 		)
 	`
 
@@ -119,9 +215,18 @@ func (p *PostgresImageRepository) CreateNewImage(ctx context.Context, image *ima
 		model.MimeType,
 		model.Status,
 		model.TransformedImageKey,
+		model.TransformedMimeType,
+		model.PageCount,
 		model.Checksum,
+		model.SHA256,
+		model.PerceptualHash,
 		model.ErrorMessage,
 		model.Transformations,
+		model.BlurHash,
+		model.DominantColor,
+		model.Palette,
+		model.OriginalProperties,
+		model.TransformedProperties,
 		model.UpdatedAt,
 		model.CreatedAt,
 	)
@@ -169,7 +274,8 @@ func (p *PostgresImageRepository) FindAllImages(ctx context.Context, req *images
 
 	query := `
 		SELECT id, original_image_url, object_storage_image_key, mime_type, status,
-		       transformed_image_key, checksum, error_message, transformations, updated_at, created_at
+		       transformed_image_key, transformed_mime_type, page_count, checksum, sha256, perceptual_hash, error_message, transformations,
+		       blurhash, dominant_color, palette, original_properties, transformed_properties, updated_at, created_at
 		FROM images
 		ORDER BY created_at DESC
 		LIMIT $1 OFFSET $2
@@ -192,9 +298,18 @@ func (p *PostgresImageRepository) FindAllImages(ctx context.Context, req *images
 			&model.MimeType,
 			&model.Status,
 			&model.TransformedImageKey,
+			&model.TransformedMimeType,
+			&model.PageCount,
 			&model.Checksum,
+			&model.SHA256,
+			&model.PerceptualHash,
 			&model.ErrorMessage,
 			&model.Transformations,
+			&model.BlurHash,
+			&model.DominantColor,
+			&model.Palette,
+			&model.OriginalProperties,
+			&model.TransformedProperties,
 			&model.UpdatedAt,
 			&model.CreatedAt,
 		)
@@ -252,7 +367,8 @@ func (p *PostgresImageRepository) FindImageByID(ctx context.Context, id string)
 
 	query := `
 		SELECT id, original_image_url, object_storage_image_key, mime_type, status,
-		       transformed_image_key, checksum, error_message, transformations, updated_at, created_at
+		       transformed_image_key, transformed_mime_type, page_count, checksum, sha256, perceptual_hash, error_message, transformations,
+		       blurhash, dominant_color, palette, original_properties, transformed_properties, updated_at, created_at
 		FROM images
 		WHERE id = $1
 	`
@@ -265,9 +381,18 @@ func (p *PostgresImageRepository) FindImageByID(ctx context.Context, id string)
 		&model.MimeType,
 		&model.Status,
 		&model.TransformedImageKey,
+		&model.TransformedMimeType,
+		&model.PageCount,
 		&model.Checksum,
+		&model.SHA256,
+		&model.PerceptualHash,
 		&model.ErrorMessage,
 		&model.Transformations,
+		&model.BlurHash,
+		&model.DominantColor,
+		&model.Palette,
+		&model.OriginalProperties,
+		&model.TransformedProperties,
 		&model.UpdatedAt,
 		&model.CreatedAt,
 	)
@@ -289,14 +414,277 @@ func (p *PostgresImageRepository) FindImageByID(ctx context.Context, id string)
 	return domainImage, nil
 }
 
+// FindImagesByChecksum implements ports.ImageRepository.
+func (p *PostgresImageRepository) FindImagesByChecksum(ctx context.Context, checksum string) ([]images.Image, error) {
+	ctx, span := tracer.Start(ctx, "PostgresImageRepository.FindImagesByChecksum")
+	defer span.End()
+
+	query := `
+		SELECT id, original_image_url, object_storage_image_key, mime_type, status,
+		       transformed_image_key, transformed_mime_type, page_count, checksum, sha256, perceptual_hash, error_message, transformations,
+		       blurhash, dominant_color, palette, original_properties, transformed_properties, updated_at, created_at
+		FROM images
+		WHERE sha256 = $1 OR checksum = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := p.pool.Query(ctx, query, checksum)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to query images: %w", err)
+	}
+	defer rows.Close()
+
+	imagesList := []images.Image{}
+	for rows.Next() {
+		var model imageModel
+		err := rows.Scan(
+			&model.ID,
+			&model.OriginalImageURL,
+			&model.ObjectStorageImageKey,
+			&model.MimeType,
+			&model.Status,
+			&model.TransformedImageKey,
+			&model.TransformedMimeType,
+			&model.PageCount,
+			&model.Checksum,
+			&model.SHA256,
+			&model.PerceptualHash,
+			&model.ErrorMessage,
+			&model.Transformations,
+			&model.BlurHash,
+			&model.DominantColor,
+			&model.Palette,
+			&model.OriginalProperties,
+			&model.TransformedProperties,
+			&model.UpdatedAt,
+			&model.CreatedAt,
+		)
+		if err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to scan image row: %w", err)
+		}
+
+		domainImage, err := model.toDomain()
+		if err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to convert to domain: %w", err)
+		}
+
+		imagesList = append(imagesList, *domainImage)
+	}
+
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("count", len(imagesList)))
+	return imagesList, nil
+}
+
+// FindExpiredUploads implements ports.ImageRepository.
+func (p *PostgresImageRepository) FindExpiredUploads(ctx context.Context, reservedBefore time.Time, limit int) ([]images.Image, error) {
+	ctx, span := tracer.Start(ctx, "PostgresImageRepository.FindExpiredUploads")
+	defer span.End()
+
+	query := `
+		SELECT id, original_image_url, object_storage_image_key, mime_type, status,
+		       transformed_image_key, transformed_mime_type, page_count, checksum, sha256, perceptual_hash, error_message, transformations,
+		       blurhash, dominant_color, palette, original_properties, transformed_properties, updated_at, created_at
+		FROM images
+		WHERE status = $1 AND created_at < $2
+		ORDER BY created_at
+		LIMIT $3
+	`
+
+	rows, err := p.pool.Query(ctx, query, images.StatusAwaitingUpload, reservedBefore, limit)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to query images: %w", err)
+	}
+	defer rows.Close()
+
+	imagesList := []images.Image{}
+	for rows.Next() {
+		var model imageModel
+		err := rows.Scan(
+			&model.ID,
+			&model.OriginalImageURL,
+			&model.ObjectStorageImageKey,
+			&model.MimeType,
+			&model.Status,
+			&model.TransformedImageKey,
+			&model.TransformedMimeType,
+			&model.PageCount,
+			&model.Checksum,
+			&model.SHA256,
+			&model.PerceptualHash,
+			&model.ErrorMessage,
+			&model.Transformations,
+			&model.BlurHash,
+			&model.DominantColor,
+			&model.Palette,
+			&model.OriginalProperties,
+			&model.TransformedProperties,
+			&model.UpdatedAt,
+			&model.CreatedAt,
+		)
+		if err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to scan image row: %w", err)
+		}
+
+		domainImage, err := model.toDomain()
+		if err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to convert to domain: %w", err)
+		}
+
+		imagesList = append(imagesList, *domainImage)
+	}
+
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("count", len(imagesList)))
+	return imagesList, nil
+}
+
+// FindSimilarImages implements ports.ImageRepository.
+// The perceptual_hash column is split into four indexed 16 bit bands. Two hashes at most maxDistance bits
+// apart have a band at most maxDistance/4 bits apart, so looking up every value that close to each band of
+// the hash finds every similar image through the indexes, and only those candidates are compared in full.
+func (p *PostgresImageRepository) FindSimilarImages(ctx context.Context, image *images.Image, maxDistance int, limit int) ([]images.SimilarImage, error) {
+	ctx, span := tracer.Start(ctx, "PostgresImageRepository.FindSimilarImages")
+	defer span.End()
+
+	hash, err := images.ParsePerceptualHash(image.PerceptualHash)
+	if err != nil {
+		return nil, err
+	}
+	bands := bandCandidates(hash, maxDistance/perceptualHashBands)
+
+	query := `
+		SELECT id, original_image_url, object_storage_image_key, mime_type, status,
+		       transformed_image_key, transformed_mime_type, page_count, checksum, sha256, perceptual_hash, error_message, transformations,
+		       blurhash, dominant_color, palette, original_properties, transformed_properties, updated_at, created_at,
+		       bit_count((perceptual_hash # $2)::bit(64)) AS distance
+		FROM images
+		WHERE id <> $1
+		  AND (phash_band0 = ANY($3) OR phash_band1 = ANY($4) OR phash_band2 = ANY($5) OR phash_band3 = ANY($6))
+		  AND bit_count((perceptual_hash # $2)::bit(64)) <= $7
+		ORDER BY distance, created_at DESC
+		LIMIT $8
+	`
+
+	rows, err := p.pool.Query(ctx, query, image.ID, int64(hash), bands[0], bands[1], bands[2], bands[3], maxDistance, limit)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to query similar images: %w", err)
+	}
+	defer rows.Close()
+
+	similarImages := []images.SimilarImage{}
+	for rows.Next() {
+		var model imageModel
+		var distance int
+		err := rows.Scan(
+			&model.ID,
+			&model.OriginalImageURL,
+			&model.ObjectStorageImageKey,
+			&model.MimeType,
+			&model.Status,
+			&model.TransformedImageKey,
+			&model.TransformedMimeType,
+			&model.PageCount,
+			&model.Checksum,
+			&model.SHA256,
+			&model.PerceptualHash,
+			&model.ErrorMessage,
+			&model.Transformations,
+			&model.BlurHash,
+			&model.DominantColor,
+			&model.Palette,
+			&model.OriginalProperties,
+			&model.TransformedProperties,
+			&model.UpdatedAt,
+			&model.CreatedAt,
+			&distance,
+		)
+		if err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to scan image row: %w", err)
+		}
+
+		domainImage, err := model.toDomain()
+		if err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to convert to domain: %w", err)
+		}
+
+		similarImages = append(similarImages, images.SimilarImage{Image: *domainImage, Distance: distance})
+	}
+
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	span.SetAttributes(
+		attribute.String("image.id", image.ID.String()),
+		attribute.Int("max_distance", maxDistance),
+		attribute.Int("count", len(similarImages)),
+	)
+	return similarImages, nil
+}
+
 // UpdateImage implements ports.ImageRepository.
 func (p *PostgresImageRepository) UpdateImage(ctx context.Context, image *images.Image) error {
 	ctx, span := tracer.Start(ctx, "PostgresImageRepository.UpdateImage")
 	defer span.End()
 
+	updated, err := p.updateImage(ctx, image, "")
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	if !updated {
+		return ports.ErrImageNotFound
+	}
+
+	span.SetAttributes(attribute.String("image.id", image.ID.String()))
+	return nil
+}
+
+// UpdateImageFromStatus implements ports.ImageRepository.
+func (p *PostgresImageRepository) UpdateImageFromStatus(ctx context.Context, image *images.Image, status string) error {
+	ctx, span := tracer.Start(ctx, "PostgresImageRepository.UpdateImageFromStatus")
+	defer span.End()
+
+	updated, err := p.updateImage(ctx, image, status)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	if !updated {
+		return ports.ErrImageStatusChanged
+	}
+
+	span.SetAttributes(attribute.String("image.id", image.ID.String()))
+	return nil
+}
+
+// updateImage writes every column of an image, only while it still has the given status unless that is empty.
+// It reports whether a row was updated.
+func (p *PostgresImageRepository) updateImage(ctx context.Context, image *images.Image, status string) (bool, error) {
 	model, err := fromDomain(image)
 	if err != nil {
-		return fmt.Errorf("failed to convert to persistence model: %w", err)
+		return false, fmt.Errorf("failed to convert to persistence model: %w", err)
 	}
 
 	query := `
@@ -306,11 +694,21 @@ func (p *PostgresImageRepository) UpdateImage(ctx context.Context, image *images
 		    mime_type = $4,
 		    status = $5,
 		    transformed_image_key = $6,
-		    checksum = $7,
-		    error_message = $8,
-		    transformations = $9,
-		    updated_at = $10
+		    transformed_mime_type = $7,
+		    page_count = $8,
+		    checksum = $9,
+		    sha256 = $10,
+		    perceptual_hash = $11,
+		    error_message = $12,
+		    transformations = $13,
+		    blurhash = $14,
+		    dominant_color = $15,
+		    palette = $16,
+		    original_properties = $17,
+		    transformed_properties = $18,
+		    updated_at = $19
 		WHERE id = $1
+		  AND ($20::text = '' OR status = $20)
 	`
 
 	result, err := p.pool.Exec(ctx, query,
@@ -320,20 +718,122 @@ func (p *PostgresImageRepository) UpdateImage(ctx context.Context, image *images
 		model.MimeType,
 		model.Status,
 		model.TransformedImageKey,
+		model.TransformedMimeType,
+		model.PageCount,
 		model.Checksum,
+		model.SHA256,
+		model.PerceptualHash,
 		model.ErrorMessage,
 		model.Transformations,
+		model.BlurHash,
+		model.DominantColor,
+		model.Palette,
+		model.OriginalProperties,
+		model.TransformedProperties,
 		time.Now(),
+		status,
 	)
+	if err != nil {
+		return false, fmt.Errorf("failed to update image: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// AcquireOriginal implements ports.ImageRepository.
+func (p *PostgresImageRepository) AcquireOriginal(ctx context.Context, sha256 string, imageID string, key string) (string, error) {
+	ctx, span := tracer.Start(ctx, "PostgresImageRepository.AcquireOriginal")
+	defer span.End()
+
+	// The upsert takes a row lock, so concurrent uploads of the same bytes agree on one object
+	query := `
+		INSERT INTO raw_objects (sha256, object_key)
+		VALUES ($1, $2)
+		ON CONFLICT (sha256) DO UPDATE SET object_key = raw_objects.object_key
+		RETURNING object_key
+	`
+
+	// A redelivered message acquires again, which must not count the image twice
+	refQuery := `
+		INSERT INTO raw_object_refs (sha256, image_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+
+	var storedKey string
+	var referenced bool
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		if err := tx.QueryRow(ctx, query, sha256, key).Scan(&storedKey); err != nil {
+			return err
+		}
+
+		result, err := tx.Exec(ctx, refQuery, sha256, imageID)
+		if err != nil {
+			return err
+		}
+
+		referenced = result.RowsAffected() > 0
+		return nil
+	})
 	if err != nil {
 		span.RecordError(err)
-		return fmt.Errorf("failed to update image: %w", err)
+		return "", fmt.Errorf("failed to acquire original: %w", err)
 	}
 
-	if result.RowsAffected() == 0 {
-		return ports.ErrImageNotFound
+	span.SetAttributes(
+		attribute.String("image.id", imageID),
+		attribute.String("image.sha256", sha256),
+		attribute.Bool("new_reference", referenced),
+	)
+	return storedKey, nil
+}
+
+// ReleaseOriginal implements ports.ImageRepository.
+func (p *PostgresImageRepository) ReleaseOriginal(ctx context.Context, sha256 string, imageID string) (string, error) {
+	ctx, span := tracer.Start(ctx, "PostgresImageRepository.ReleaseOriginal")
+	defer span.End()
+
+	// Locking the original keeps an upload of the same bytes from referencing it while it is deleted
+	lockQuery := `SELECT object_key FROM raw_objects WHERE sha256 = $1 FOR UPDATE`
+
+	refQuery := `DELETE FROM raw_object_refs WHERE sha256 = $1 AND image_id = $2`
+
+	deleteQuery := `
+		DELETE FROM raw_objects
+		WHERE sha256 = $1
+		  AND NOT EXISTS (SELECT 1 FROM raw_object_refs WHERE raw_object_refs.sha256 = $1)
+		RETURNING object_key
+	`
+
+	var objectKey string
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		var lockedKey string
+		if err := tx.QueryRow(ctx, lockQuery, sha256).Scan(&lockedKey); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
+
+		if _, err := tx.Exec(ctx, refQuery, sha256, imageID); err != nil {
+			return err
+		}
+
+		err := tx.QueryRow(ctx, deleteQuery, sha256).Scan(&objectKey)
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Other images still reference the original
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		return "", fmt.Errorf("failed to release original: %w", err)
 	}
 
-	span.SetAttributes(attribute.String("image.id", image.ID.String()))
-	return nil
+	span.SetAttributes(
+		attribute.String("image.id", imageID),
+		attribute.String("image.sha256", sha256),
+	)
+	return objectKey, nil
 }
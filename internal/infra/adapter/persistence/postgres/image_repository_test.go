@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"math/bits"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// maxSimilarityDistance is the largest max_distance FindSimilarImagesRequest accepts
+const maxSimilarityDistance = 11
+
+func TestBandCandidates(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	for maxDistance := 0; maxDistance <= maxSimilarityDistance; maxDistance++ {
+		hash := rng.Uint64()
+		candidates := bandCandidates(hash, maxDistance/perceptualHashBands)
+
+		// The differing bits spread as evenly over the bands as they can is the hardest case,
+		// random spreads cover the rest
+		others := []uint64{hash ^ evenlySpreadBits(maxDistance)}
+		for range 1000 {
+			others = append(others, hash^randomBits(rng, rng.IntN(maxDistance+1)))
+		}
+
+		for _, other := range others {
+			if distance := bits.OnesCount64(hash ^ other); distance > maxDistance {
+				t.Fatalf("hash %016x is %d bits away, want at most %d", other, distance, maxDistance)
+			}
+
+			if !matchesBand(candidates, other) {
+				t.Errorf("max distance %d: hash %016x, %d bits from %016x, is in no band candidates",
+					maxDistance, other, bits.OnesCount64(hash^other), hash)
+			}
+		}
+	}
+}
+
+func TestBandCandidatesCount(t *testing.T) {
+	// Every value at most radius bits away, so 1 + 16 + 120 values for a radius of 2
+	candidates := bandCandidates(0x0123456789abcdef, maxSimilarityDistance/perceptualHashBands)
+
+	for band, values := range candidates {
+		if len(values) != 137 {
+			t.Errorf("band %d has %d candidates, want 137", band, len(values))
+		}
+	}
+}
+
+// evenlySpreadBits sets distance bits, taking one from each band in turn
+func evenlySpreadBits(distance int) uint64 {
+	var mask uint64
+	for i := range distance {
+		band := i % perceptualHashBands
+		mask |= 1 << (16*band + i/perceptualHashBands)
+	}
+	return mask
+}
+
+// randomBits sets distance distinct random bits
+func randomBits(rng *rand.Rand, distance int) uint64 {
+	var mask uint64
+	for bits.OnesCount64(mask) < distance {
+		mask |= 1 << rng.IntN(64)
+	}
+	return mask
+}
+
+// matchesBand reports whether a band of hash is among the candidates of that band, as the query looks it up
+func matchesBand(candidates [perceptualHashBands][]int32, hash uint64) bool {
+	for band := range perceptualHashBands {
+		value := int32(uint16(hash >> (48 - 16*band)))
+		if slices.Contains(candidates[band], value) {
+			return true
+		}
+	}
+	return false
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -13,15 +15,18 @@ import (
 	"github.com/taldoflemis/sora-henkan/internal/core/domain/images"
 	"github.com/taldoflemis/sora-henkan/internal/core/ports"
 	"github.com/taldoflemis/sora-henkan/pkg/http/api"
+	"github.com/taldoflemis/sora-henkan/settings"
 )
 
 type ImageHandler struct {
-	imageUseCase *application.ImageUseCase
+	imageUseCase           *application.ImageUseCase
+	imageProcessorSettings *settings.ImageProcessorSettings
 }
 
-func NewImageHandler(imageUseCase *application.ImageUseCase) *ImageHandler {
+func NewImageHandler(imageUseCase *application.ImageUseCase, imageProcessorSettings *settings.ImageProcessorSettings) *ImageHandler {
 	return &ImageHandler{
-		imageUseCase: imageUseCase,
+		imageUseCase:           imageUseCase,
+		imageProcessorSettings: imageProcessorSettings,
 	}
 }
 
@@ -30,10 +35,18 @@ func (h *ImageHandler) RegisterRoute(g *echo.Group) {
 
 	imageHandlerGroup.GET("/", h.ListImages)
 	imageHandlerGroup.GET("/sse", h.GetAllImagesRealtimeUpdates)
+	imageHandlerGroup.GET("/by-checksum", h.FindImagesByChecksum)
 	imageHandlerGroup.GET("/:id/sse", h.GetImageRealtimeUpdate)
 	imageHandlerGroup.GET("/:id", h.GetImage)
 	imageHandlerGroup.GET("/:id/metadata", h.GetImageMetadata)
+	imageHandlerGroup.GET("/:id/similar", h.FindSimilarImages)
+	imageHandlerGroup.GET("/:id/original", h.GetOriginalImage)
+	imageHandlerGroup.GET("/:id/transformed", h.GetTransformedImage)
+	imageHandlerGroup.GET("/:id/render", h.RenderImage)
 	imageHandlerGroup.POST("/", h.CreateImage)
+	imageHandlerGroup.POST("/upload", h.UploadImage)
+	imageHandlerGroup.POST("/uploads", h.CreateUpload)
+	imageHandlerGroup.POST("/:id/uploads/complete", h.CompleteUpload)
 	imageHandlerGroup.PUT("/", h.UpdateImage)
 	imageHandlerGroup.DELETE("/:id", h.DeleteImage)
 }
@@ -74,6 +87,38 @@ func (h *ImageHandler) ListImages(c echo.Context) error {
 	return c.JSON(http.StatusOK, apiResp)
 }
 
+// FindImagesByChecksum lists the images whose original has the given checksum,
+// so clients can find out whether an image was stored before
+func (h *ImageHandler) FindImagesByChecksum(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := images.FindImagesByChecksumRequest{}
+
+	err := c.Bind(&req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+
+	found, err := h.imageUseCase.FindImagesByChecksum(ctx, &req)
+	if err != nil {
+		return err
+	}
+
+	apiImages := make([]api.Image, 0, len(found))
+	for _, domainImage := range found {
+		apiImage, err := api.ConvertDomainImageToAPI(&domainImage)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to convert image", slog.String("error", err.Error()))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to convert images")
+		}
+		apiImages = append(apiImages, *apiImage)
+	}
+
+	return c.JSON(http.StatusOK, api.FindImagesByChecksumResponse{
+		Data: apiImages,
+	})
+}
+
 func (h *ImageHandler) GetAllImagesRealtimeUpdates(c echo.Context) error {
 	ctx := context.Background()
 	fluser, ok := c.Response().Writer.(http.Flusher)
@@ -97,7 +142,12 @@ func (h *ImageHandler) GetAllImagesRealtimeUpdates(c echo.Context) error {
 		case <-c.Request().Context().Done():
 			slog.InfoContext(ctx, "client closed connection")
 			return nil
-		case update := <-imageUpdates:
+		case update, ok := <-imageUpdates:
+			if !ok {
+				// The event subscription ended, the client reconnects to a fresh one
+				slog.WarnContext(ctx, "image updates closed")
+				return nil
+			}
 			// Convert domain image to API image
 			apiImage, err := api.ConvertDomainImageToAPI(update)
 			if err != nil {
@@ -150,7 +200,12 @@ func (h *ImageHandler) GetImageRealtimeUpdate(c echo.Context) error {
 		case <-notify:
 			slog.InfoContext(ctx, "client closed connection")
 			return nil
-		case update := <-imageUpdates:
+		case update, ok := <-imageUpdates:
+			if !ok {
+				// The event subscription ended, the client reconnects to a fresh one
+				slog.WarnContext(ctx, "image updates closed")
+				return nil
+			}
 			// Convert domain image to API image
 			apiImage, err := api.ConvertDomainImageToAPI(update)
 			if err != nil {
@@ -210,6 +265,143 @@ func (h *ImageHandler) GetImageMetadata(c echo.Context) error {
 	return c.JSON(http.StatusOK, metadata)
 }
 
+// FindSimilarImages lists the near-duplicates of an image by the Hamming distance between their perceptual hashes
+func (h *ImageHandler) FindSimilarImages(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := images.FindSimilarImagesRequest{}
+
+	err := c.Bind(&req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+
+	similarImages, err := h.imageUseCase.FindSimilarImages(ctx, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, ports.ErrImageNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, "Image not found")
+		case errors.Is(err, images.ErrPerceptualHashNotAvailable):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		return err
+	}
+
+	apiSimilarImages := make([]api.SimilarImage, 0, len(similarImages))
+	for _, similarImage := range similarImages {
+		apiImage, err := api.ConvertDomainImageToAPI(&similarImage.Image)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to convert image", slog.String("error", err.Error()))
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to convert images")
+		}
+		apiSimilarImages = append(apiSimilarImages, api.SimilarImage{
+			Image:    *apiImage,
+			Distance: similarImage.Distance,
+		})
+	}
+
+	return c.JSON(http.StatusOK, api.FindSimilarImagesResponse{
+		Data: apiSimilarImages,
+	})
+}
+
+func (h *ImageHandler) GetOriginalImage(c echo.Context) error {
+	return h.serveImage(c, images.ImageVariantOriginal)
+}
+
+func (h *ImageHandler) GetTransformedImage(c echo.Context) error {
+	return h.serveImage(c, images.ImageVariantTransformed)
+}
+
+// serveImage either streams the image bytes or redirects to the bucket, depending on the serve mode
+func (h *ImageHandler) serveImage(c echo.Context, variant images.ImageVariant) error {
+	ctx := c.Request().Context()
+	id := c.Param("id")
+
+	var page int
+	if rawPage := c.QueryParam("page"); rawPage != "" {
+		var err error
+		page, err = strconv.Atoi(rawPage)
+		if err != nil || page < 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid page")
+		}
+	}
+
+	if h.imageProcessorSettings.ServeMode == settings.ServeModeRedirect {
+		downloadURL, err := h.imageUseCase.GetImageDownloadURL(ctx, id, variant, page)
+		if err != nil {
+			return mapImageContentError(err)
+		}
+
+		return c.Redirect(http.StatusTemporaryRedirect, downloadURL)
+	}
+
+	content, err := h.imageUseCase.GetImageContent(ctx, id, variant, page)
+	if err != nil {
+		return mapImageContentError(err)
+	}
+	defer content.Body.Close()
+
+	c.Response().Header().Set(echo.HeaderContentType, content.MimeType)
+	c.Response().Header().Set("ETag", content.ETag)
+
+	// ServeContent handles Range, If-None-Match and If-Modified-Since for us
+	http.ServeContent(c.Response(), c.Request(), "", content.ModifiedAt, content.Body)
+
+	return nil
+}
+
+func (h *ImageHandler) RenderImage(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := images.RenderImageRequest{
+		ID:        c.Param("id"),
+		Ops:       c.QueryParam("ops"),
+		Signature: c.QueryParam("signature"),
+	}
+
+	if expires := c.QueryParam("expires"); expires != "" {
+		var err error
+		req.Expires, err = strconv.ParseInt(expires, 10, 64)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid expires")
+		}
+	}
+
+	content, err := h.imageUseCase.RenderImage(ctx, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, images.ErrInvalidRenderOps), errors.Is(err, images.ErrInvalidForImage):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		case errors.Is(err, images.ErrInvalidRenderSignature):
+			return echo.NewHTTPError(http.StatusForbidden, "Invalid signature")
+		case errors.Is(err, images.ErrRenderURLExpired):
+			return echo.NewHTTPError(http.StatusForbidden, "Render URL expired")
+		}
+		return mapImageContentError(err)
+	}
+	defer content.Body.Close()
+
+	c.Response().Header().Set(echo.HeaderContentType, content.MimeType)
+	c.Response().Header().Set("ETag", content.ETag)
+	// A rendition never changes for a given image and ops
+	c.Response().Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+
+	http.ServeContent(c.Response(), c.Request(), "", content.ModifiedAt, content.Body)
+
+	return nil
+}
+
+func mapImageContentError(err error) error {
+	if errors.Is(err, ports.ErrImageNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Image not found")
+	}
+	if errors.Is(err, images.ErrImageContentNotAvailable) {
+		return echo.NewHTTPError(http.StatusNotFound, "Image content not available")
+	}
+	return err
+}
+
 func (h *ImageHandler) CreateImage(c echo.Context) error {
 	ctx := c.Request().Context()
 	req := api.CreateImageRequest{}
@@ -243,6 +435,138 @@ func (h *ImageHandler) CreateImage(c echo.Context) error {
 	return c.JSON(http.StatusCreated, apiResp)
 }
 
+func (h *ImageHandler) UploadImage(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, h.imageProcessorSettings.MaxUploadSize)
+
+	// Read the parts one by one so the file is streamed instead of buffered
+	reader, err := c.Request().MultipartReader()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid multipart body")
+	}
+
+	var transformations []images.TransformationRequest
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return echo.NewHTTPError(http.StatusBadRequest, "file is required")
+		}
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid multipart body")
+		}
+
+		switch part.FormName() {
+		case "transformations":
+			apiTransformations := []api.TransformationRequest{}
+			if err := json.NewDecoder(part).Decode(&apiTransformations); err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid transformations")
+			}
+
+			// Convert API transformations to domain transformations
+			transformations, err = api.ConvertAPITransformationsToDomain(apiTransformations)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+
+		case "file":
+			if transformations == nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "transformations must be sent before file")
+			}
+
+			domainReq := &images.UploadImageRequest{
+				Filename:        part.FileName(),
+				Transformations: transformations,
+			}
+
+			resp, err := h.imageUseCase.UploadImage(ctx, domainReq, part)
+			if err != nil {
+				var maxBytesErr *http.MaxBytesError
+				if errors.As(err, &maxBytesErr) {
+					return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "Image is too large")
+				}
+				if errors.Is(err, images.ErrDisallowedMIMEType) {
+					return echo.NewHTTPError(http.StatusUnsupportedMediaType, err.Error())
+				}
+				return err
+			}
+
+			id, err := uuid.Parse(resp.ID)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to parse image ID")
+			}
+
+			return c.JSON(http.StatusCreated, api.CreateImageResponse{Id: id})
+		}
+	}
+}
+
+func (h *ImageHandler) CreateUpload(c echo.Context) error {
+	ctx := c.Request().Context()
+	req := api.CreateUploadRequest{}
+
+	err := c.Bind(&req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	// Convert API request to domain request
+	domainReq, err := api.ConvertAPICreateUploadRequestToDomain(&req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.imageUseCase.CreateUpload(ctx, domainReq)
+	if err != nil {
+		return err
+	}
+
+	id, err := uuid.Parse(resp.ID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to parse image ID")
+	}
+
+	apiResp := api.CreateUploadResponse{
+		Id:        id,
+		UploadUrl: resp.UploadURL,
+		ExpiresAt: resp.ExpiresAt,
+	}
+
+	return c.JSON(http.StatusCreated, apiResp)
+}
+
+func (h *ImageHandler) CompleteUpload(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := images.CompleteUploadRequest{
+		ID: c.Param("id"),
+	}
+
+	image, err := h.imageUseCase.CompleteUpload(ctx, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, ports.ErrImageNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, "Image not found")
+		case errors.Is(err, images.ErrImageNotAwaitingUpload):
+			return echo.NewHTTPError(http.StatusConflict, "Image is not awaiting an upload")
+		case errors.Is(err, images.ErrUploadNotFound):
+			return echo.NewHTTPError(http.StatusConflict, "Image was not uploaded yet")
+		case errors.Is(err, images.ErrDisallowedMIMEType):
+			return echo.NewHTTPError(http.StatusUnsupportedMediaType, err.Error())
+		}
+		return err
+	}
+
+	// Convert domain image to API image
+	apiImage, err := api.ConvertDomainImageToAPI(image)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to convert image")
+	}
+
+	return c.JSON(http.StatusOK, apiImage)
+}
+
 func (h *ImageHandler) UpdateImage(c echo.Context) error {
 	ctx := c.Request().Context()
 	req := api.UpdateImageRequest{}
@@ -17,14 +17,18 @@ func CloudFlareExtractClientIPfunc(req *http.Request) string {
 	return echo.ExtractIPFromXFFHeader()(req)
 }
 
-func SSETimeoutSkipper(c echo.Context) bool {
-	// Skip timeout for SSE endpoints
+func StreamingTimeoutSkipper(c echo.Context) bool {
+	// Skip timeout for SSE endpoints, uploads and downloads, which may take longer than a regular request
 	path := c.Path()
 
 	if c.Request().Header.Get("Accept") == "text/event-stream" || strings.Contains(path, "/sse") {
 		return true
 	}
 
+	if strings.HasSuffix(path, "/upload") || strings.HasSuffix(path, "/original") || strings.HasSuffix(path, "/transformed") {
+		return true
+	}
+
 	return false
 }
 
@@ -34,7 +34,7 @@ func NewRouter(httpSettings *settings.HTTPSettings, appSettings *settings.AppSet
 	e.IPExtractor = CloudFlareExtractClientIPfunc
 	e.Use(
 		middleware.TimeoutWithConfig(middleware.TimeoutConfig{
-			Timeout: time.Duration(httpSettings.Timeout) * time.Second, Skipper: SSETimeoutSkipper,
+			Timeout: time.Duration(httpSettings.Timeout) * time.Second, Skipper: StreamingTimeoutSkipper,
 		}),
 	)
 	e.Use(middleware.Recover())
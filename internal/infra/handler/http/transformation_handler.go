@@ -0,0 +1,51 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/taldoflemis/sora-henkan/internal/core/ports"
+	"github.com/taldoflemis/sora-henkan/pkg/http/api"
+)
+
+type TransformationHandler struct {
+	transformerRegistry ports.ImageTransformerRegistry
+}
+
+func NewTransformationHandler(transformerRegistry ports.ImageTransformerRegistry) *TransformationHandler {
+	return &TransformationHandler{
+		transformerRegistry: transformerRegistry,
+	}
+}
+
+func (h *TransformationHandler) RegisterRoute(g *echo.Group) {
+	g.GET("v1/transformations", h.ListTransformations)
+}
+
+// ListTransformations describes every registered transformation, so clients can build their forms from it
+func (h *TransformationHandler) ListTransformations(c echo.Context) error {
+	registrations := h.transformerRegistry.Registrations()
+
+	transformations := make([]api.Transformation, 0, len(registrations))
+	for _, registration := range registrations {
+		aliases := registration.Aliases
+		if aliases == nil {
+			aliases = []string{}
+		}
+
+		examples := make([]map[string]interface{}, 0, len(registration.Examples))
+		examples = append(examples, registration.Examples...)
+
+		transformations = append(transformations, api.Transformation{
+			Name:         registration.Transformer.Name(),
+			Aliases:      aliases,
+			Description:  registration.Description,
+			ConfigSchema: registration.ConfigSchema,
+			Examples:     examples,
+		})
+	}
+
+	return c.JSON(http.StatusOK, api.ListTransformationsResponse{
+		Data: transformations,
+	})
+}
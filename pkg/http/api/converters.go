@@ -1,171 +1,105 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/taldoflemis/sora-henkan/internal/core/domain/images"
 )
 
-// ConvertAPITransformationsToDomain converts API TransformationRequest to domain TransformationRequest
+// ConvertAPITransformationsToDomain converts API TransformationRequest to domain TransformationRequest.
+// Each config is decoded into the typed config of the transformation it names, so values of the wrong type are rejected,
+// and the transformation validates the rest.
 func ConvertAPITransformationsToDomain(apiTransformations []TransformationRequest) ([]images.TransformationRequest, error) {
 	domainTransformations := make([]images.TransformationRequest, 0, len(apiTransformations))
 
 	for i, apiTrans := range apiTransformations {
-		discriminator, err := apiTrans.Discriminator()
+		apiTrans, err := resolveTransformationAlias(apiTrans)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get discriminator for transformation %d: %w", i, err)
+			return nil, fmt.Errorf("failed to read transformation %d: %w", i, err)
 		}
 
-		config := make(map[string]interface{})
-
-		switch discriminator {
-		case "resize":
-			resize, err := apiTrans.AsResizeTransformation()
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse resize transformation %d: %w", i, err)
-			}
-			config["width"] = resize.Config.Width
-			config["height"] = resize.Config.Height
-
-		case "grayscale":
-			// Grayscale has no config
-			// Empty config map is fine
-
-		case "trim":
-			trim, err := apiTrans.AsTrimTransformation()
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse trim transformation %d: %w", i, err)
-			}
-			config["threshold"] = trim.Config.Threshold
-
-		case "blur":
-			blur, err := apiTrans.AsBlurTransformation()
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse blur transformation %d: %w", i, err)
-			}
-			config["sigma"] = blur.Config.Sigma
-
-		case "rotate":
-			rotate, err := apiTrans.AsRotateTransformation()
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse rotate transformation %d: %w", i, err)
-			}
-			config["angle"] = int(rotate.Config.Angle)
-
-		default:
-			return nil, fmt.Errorf("unknown transformation type: %s", discriminator)
+		transformation, err := apiTrans.ValueByDiscriminator()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse transformation %d: %w", i, err)
+		}
+
+		// The typed transformation is encoded back to read its config as the map the pipeline takes,
+		// options left out stay out so they fall back to the transformer defaults
+		data, err := json.Marshal(transformation)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode transformation %d: %w", i, err)
 		}
 
-		domainTransformations = append(domainTransformations, images.TransformationRequest{
-			Name:   discriminator,
-			Config: config,
-		})
+		var domainTrans images.TransformationRequest
+		if err := json.Unmarshal(data, &domainTrans); err != nil {
+			return nil, fmt.Errorf("failed to decode transformation %d: %w", i, err)
+		}
+		if domainTrans.Config == nil {
+			domainTrans.Config = make(map[string]interface{})
+		}
+
+		domainTransformations = append(domainTransformations, domainTrans)
 	}
 
 	return domainTransformations, nil
 }
 
+// resolveTransformationAlias renames a transformation requested by one of its aliases to its name,
+// since the discriminator maps a single name to each transformation
+func resolveTransformationAlias(apiTrans TransformationRequest) (TransformationRequest, error) {
+	discriminator, err := apiTrans.Discriminator()
+	if err != nil {
+		return apiTrans, err
+	}
+
+	name := images.TransformationName(discriminator)
+	if name == discriminator {
+		return apiTrans, nil
+	}
+
+	data, err := apiTrans.MarshalJSON()
+	if err != nil {
+		return apiTrans, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return apiTrans, err
+	}
+
+	fields["name"], err = json.Marshal(name)
+	if err != nil {
+		return apiTrans, err
+	}
+
+	data, err = json.Marshal(fields)
+	if err != nil {
+		return apiTrans, err
+	}
+
+	var resolved TransformationRequest
+	return resolved, resolved.UnmarshalJSON(data)
+}
+
 // ConvertDomainTransformationsToAPI converts domain TransformationRequest to API TransformationRequest
 func ConvertDomainTransformationsToAPI(domainTransformations []images.TransformationRequest) ([]TransformationRequest, error) {
 	apiTransformations := make([]TransformationRequest, 0, len(domainTransformations))
 
 	for i, domainTrans := range domainTransformations {
-		var apiTrans TransformationRequest
+		config := domainTrans.Config
+		if config == nil {
+			config = make(map[string]interface{})
+		}
 
-		switch domainTrans.Name {
-		case "resize":
-			width, ok := domainTrans.Config["width"].(int)
-			if !ok {
-				if widthFloat, ok := domainTrans.Config["width"].(float64); ok {
-					width = int(widthFloat)
-				} else {
-					return nil, fmt.Errorf("resize transformation %d: missing or invalid width", i)
-				}
-			}
-
-			height, ok := domainTrans.Config["height"].(int)
-			if !ok {
-				if heightFloat, ok := domainTrans.Config["height"].(float64); ok {
-					height = int(heightFloat)
-				} else {
-					return nil, fmt.Errorf("resize transformation %d: missing or invalid height", i)
-				}
-			}
-
-			err := apiTrans.FromResizeTransformation(ResizeTransformation{
-				Name: ResizeTransformationNameResize,
-				Config: ResizeConfig{
-					Width:  width,
-					Height: height,
-				},
-			})
-			if err != nil {
-				return nil, fmt.Errorf("failed to create resize transformation %d: %w", i, err)
-			}
-
-		case "grayscale":
-			err := apiTrans.FromGrayscaleTransformation(GrayscaleTransformation{
-				Name:   GrayscaleTransformationNameGrayscale,
-				Config: GrayscaleConfig{},
-			})
-			if err != nil {
-				return nil, fmt.Errorf("failed to create grayscale transformation %d: %w", i, err)
-			}
-
-		case "trim":
-			threshold, ok := domainTrans.Config["threshold"].(float64)
-			if !ok {
-				return nil, fmt.Errorf("trim transformation %d: missing or invalid threshold", i)
-			}
-
-			err := apiTrans.FromTrimTransformation(TrimTransformation{
-				Name: TrimTransformationNameTrim,
-				Config: TrimConfig{
-					Threshold: threshold,
-				},
-			})
-			if err != nil {
-				return nil, fmt.Errorf("failed to create trim transformation %d: %w", i, err)
-			}
-
-		case "blur":
-			sigma, ok := domainTrans.Config["sigma"].(float64)
-			if !ok {
-				return nil, fmt.Errorf("blur transformation %d: missing or invalid sigma", i)
-			}
-
-			err := apiTrans.FromBlurTransformation(BlurTransformation{
-				Name: BlurTransformationNameBlur,
-				Config: BlurConfig{
-					Sigma: sigma,
-				},
-			})
-			if err != nil {
-				return nil, fmt.Errorf("failed to create blur transformation %d: %w", i, err)
-			}
-
-		case "rotate":
-			angle, ok := domainTrans.Config["angle"].(int)
-			if !ok {
-				if angleFloat, ok := domainTrans.Config["angle"].(float64); ok {
-					angle = int(angleFloat)
-				} else {
-					return nil, fmt.Errorf("rotate transformation %d: missing or invalid angle", i)
-				}
-			}
-
-			err := apiTrans.FromRotateTransformation(RotateTransformation{
-				Name: RotateTransformationNameRotate,
-				Config: RotateConfig{
-					Angle: RotateConfigAngle(angle),
-				},
-			})
-			if err != nil {
-				return nil, fmt.Errorf("failed to create rotate transformation %d: %w", i, err)
-			}
-
-		default:
-			return nil, fmt.Errorf("unknown transformation type: %s", domainTrans.Name)
+		data, err := json.Marshal(images.TransformationRequest{Name: domainTrans.Name, Config: config})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode transformation %d: %w", i, err)
+		}
+
+		var apiTrans TransformationRequest
+		if err := apiTrans.UnmarshalJSON(data); err != nil {
+			return nil, fmt.Errorf("failed to create transformation %d: %w", i, err)
 		}
 
 		apiTransformations = append(apiTransformations, apiTrans)
@@ -187,9 +121,16 @@ func ConvertDomainImageToAPI(domainImage *images.Image) (*Image, error) {
 		ObjectStorageImageKey: &domainImage.ObjectStorageImageKey,
 		TransformedImageKey:   &domainImage.TransformedImageKey,
 		MimeType:              &domainImage.MimeType,
+		TransformedMimeType:   &domainImage.TransformedMimeType,
 		Checksum:              &domainImage.Checksum,
+		Sha256:                &domainImage.SHA256,
+		PerceptualHash:        &domainImage.PerceptualHash,
+		PageCount:             &domainImage.PageCount,
 		Status:                &domainImage.Status,
 		Transformations:       &apiTransformations,
+		Blurhash:              &domainImage.BlurHash,
+		DominantColor:         &domainImage.DominantColor,
+		Palette:               &domainImage.Palette,
 		CreatedAt:             &domainImage.CreatedAt,
 		UpdatedAt:             &domainImage.UpdatedAt,
 		ErrorMessage:          &domainImage.ErrorMessage,
@@ -223,3 +164,21 @@ func ConvertAPIUpdateImageRequestToDomain(apiReq *UpdateImageRequest) (*images.U
 		Transformations: transformations,
 	}, nil
 }
+
+// ConvertAPICreateUploadRequestToDomain converts API CreateUploadRequest to domain CreateUploadRequest
+func ConvertAPICreateUploadRequestToDomain(apiReq *CreateUploadRequest) (*images.CreateUploadRequest, error) {
+	transformations, err := ConvertAPITransformationsToDomain(apiReq.Transformations)
+	if err != nil {
+		return nil, err
+	}
+
+	filename := ""
+	if apiReq.Filename != nil {
+		filename = *apiReq.Filename
+	}
+
+	return &images.CreateUploadRequest{
+		Filename:        filename,
+		Transformations: transformations,
+	}, nil
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/taldoflemis/sora-henkan/internal/core/domain/images"
+)
+
+func TestConvertAPITransformationsToDomain(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    []images.TransformationRequest
+		wantErr bool
+	}{
+		{
+			name: "options left out stay out",
+			body: `[{"name": "resize", "config": {"width": 800}}]`,
+			want: []images.TransformationRequest{{Name: "resize", Config: map[string]any{"width": float64(800)}}},
+		},
+		{
+			name: "no options",
+			body: `[{"name": "grayscale", "config": {}}]`,
+			want: []images.TransformationRequest{{Name: "grayscale", Config: map[string]any{}}},
+		},
+		{
+			name: "alias",
+			body: `[{"name": "overlay", "config": {"storage_key": "watermarks/logo.png", "tile": true}}]`,
+			want: []images.TransformationRequest{
+				{Name: "watermark", Config: map[string]any{"storage_key": "watermarks/logo.png", "tile": true}},
+			},
+		},
+		{
+			name: "pipeline",
+			body: `[{"name": "crop", "config": {"width": 200, "height": 100}}, {"name": "blur", "config": {"sigma": 1.5}}]`,
+			want: []images.TransformationRequest{
+				{Name: "crop", Config: map[string]any{"width": float64(200), "height": float64(100)}},
+				{Name: "blur", Config: map[string]any{"sigma": 1.5}},
+			},
+		},
+		{
+			name:    "wrong type",
+			body:    `[{"name": "resize", "config": {"width": "800"}}]`,
+			wantErr: true,
+		},
+		{
+			name:    "fraction for an integer",
+			body:    `[{"name": "crop", "config": {"width": 200.5, "height": 100}}]`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown transformation",
+			body:    `[{"name": "sparkle", "config": {}}]`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var apiTransformations []TransformationRequest
+			if err := json.Unmarshal([]byte(tt.body), &apiTransformations); err != nil {
+				t.Fatalf("failed to decode body: %v", err)
+			}
+
+			got, err := ConvertAPITransformationsToDomain(apiTransformations)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ConvertAPITransformationsToDomain() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ConvertAPITransformationsToDomain() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertDomainTransformationsToAPI(t *testing.T) {
+	domainTransformations := []images.TransformationRequest{
+		{Name: "resize", Config: map[string]any{"width": float64(300), "mode": "pad", "background": "#ffffff"}},
+		{Name: "grayscale"},
+	}
+
+	apiTransformations, err := ConvertDomainTransformationsToAPI(domainTransformations)
+	if err != nil {
+		t.Fatalf("ConvertDomainTransformationsToAPI() error = %v", err)
+	}
+
+	resize, err := apiTransformations[0].AsResizeTransformation()
+	if err != nil {
+		t.Fatalf("AsResizeTransformation() error = %v", err)
+	}
+	if resize.Config.Width == nil || *resize.Config.Width != 300 || resize.Config.Mode == nil || *resize.Config.Mode != ResizeConfigModePad {
+		t.Errorf("resize config = %+v, want width 300 and mode pad", resize.Config)
+	}
+
+	// Converting back gives the same steps, with an empty config where there was none
+	got, err := ConvertAPITransformationsToDomain(apiTransformations)
+	if err != nil {
+		t.Fatalf("ConvertAPITransformationsToDomain() error = %v", err)
+	}
+
+	want := []images.TransformationRequest{
+		domainTransformations[0],
+		{Name: "grayscale", Config: map[string]any{}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip = %v, want %v", got, want)
+	}
+}
@@ -3,14 +3,20 @@ package settings
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"log/slog"
 	"net/url"
+	"os"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill-amqp/v3/pkg/amqp"
+	"github.com/ThreeDotsLabs/watermill-aws/sns"
 	"github.com/ThreeDotsLabs/watermill-aws/sqs"
 	watermillSqs "github.com/ThreeDotsLabs/watermill-aws/sqs"
 	"github.com/ThreeDotsLabs/watermill/message"
@@ -24,7 +30,9 @@ import (
 	_ "embed"
 
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	amazonsns "github.com/aws/aws-sdk-go-v2/service/sns"
 	amazonsqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqsTypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
 	transport "github.com/aws/smithy-go/endpoints"
 	wotelfloss "github.com/dentech-floss/watermill-opentelemetry-go-extra/pkg/opentelemetry"
 	"github.com/spf13/viper"
@@ -79,8 +87,24 @@ type OpenTelemetrySettings struct {
 
 type ImageProcessorSettings struct {
 	BucketName string `mapstructure:"bucket-name" validate:"required"`
+	// MaxUploadSize is the maximum size in bytes of a multipart image upload
+	MaxUploadSize int64 `mapstructure:"max-upload-size" validate:"gte=1"`
+	// PresignedURLExpiryInSec is how long presigned bucket URLs handed to clients stay valid
+	PresignedURLExpiryInSec int64 `mapstructure:"presigned-url-expiry" validate:"gte=1"`
+	// ServeMode selects whether image bytes are streamed by the API or clients are redirected to the bucket
+	ServeMode string `mapstructure:"serve-mode" validate:"oneof=stream redirect"`
+	// RenderSigningKey is the HMAC key render URLs are signed with
+	RenderSigningKey string `mapstructure:"render-signing-key" validate:"required,min=32"`
+	// FontsDir is the directory holding the fonts text transformations are rendered with
+	FontsDir string `mapstructure:"fonts-dir" validate:"required"`
 }
 
+// Image serve modes
+const (
+	ServeModeStream   = "stream"
+	ServeModeRedirect = "redirect"
+)
+
 type ObjectStorerSettings struct {
 	Endpoint        string `mapstructure:"endpoint" validate:"required"`
 	AccessKeyID     string `mapstructure:"access-key-id"`
@@ -170,6 +194,10 @@ type AWSSettings struct {
 	SecretKey string `mapstructure:"secret-key"`
 	Region    string `json:"region"`
 	Anonymous bool   `mapstructure:"anonymous"`
+	// AccountID is used to build SNS topic ARNs for fan-out subscriptions.
+	AccountID string `mapstructure:"account-id"`
+	// SNSEndpoint overrides the SNS endpoint, e.g. for LocalStack. Leave empty on AWS.
+	SNSEndpoint string `mapstructure:"sns-endpoint"`
 }
 
 func (a *AWSSettings) NewAWSConfig() (awsConfig.Config, error) {
@@ -212,6 +240,19 @@ func (a *AWSSettings) GetEndpointResolver() (func(*amazonsqs.Options), error) {
 	}), nil
 }
 
+func (a *AWSSettings) GetSNSEndpointResolver() (func(*amazonsns.Options), error) {
+	endpoint, err := url.Parse(a.SNSEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return amazonsns.WithEndpointResolverV2(sns.OverrideEndpointResolver{
+		Endpoint: transport.Endpoint{
+			URI: *endpoint,
+		},
+	}), nil
+}
+
 type AMQPSettings struct {
 	Host     string `mapstructure:"host" validate:"required"`
 	Port     int    `mapstructure:"port" validate:"required"`
@@ -333,9 +374,265 @@ func (broker *WatermillBrokerSettings) NewSubscriber() (message.Subscriber, erro
 	return subscriber, nil
 }
 
+// NewEventPublisher creates a publisher for fan-out events, where every
+// subscriber receives its own copy of each message.
+func (broker *WatermillBrokerSettings) NewEventPublisher() (message.Publisher, error) {
+	wattermilLogger := watermill.NewSlogLogger(slog.Default())
+
+	var publisher message.Publisher
+
+	switch broker.Kind {
+	case "sqs":
+		endpointResolver, err := broker.AWS.GetSNSEndpointResolver()
+		if err != nil {
+			return nil, err
+		}
+		cfg, err := broker.AWS.NewAWSConfig()
+		if err != nil {
+			return nil, err
+		}
+
+		castedConfig := (cfg).(aws.Config)
+
+		optFns := make([]func(*amazonsns.Options), 0)
+
+		if broker.AWS.SNSEndpoint != "" {
+			optFns = append(optFns, endpointResolver)
+		}
+
+		topicResolver, err := sns.NewGenerateArnTopicResolver(broker.AWS.AccountID, broker.AWS.Region)
+		if err != nil {
+			return nil, err
+		}
+
+		snsPublisher, err := sns.NewPublisher(sns.PublisherConfig{
+			AWSConfig:     castedConfig,
+			OptFns:        optFns,
+			TopicResolver: topicResolver,
+		}, wattermilLogger)
+		if err != nil {
+			return nil, err
+		}
+
+		publisher = snsPublisher
+	case "amqp":
+		// AMQP pub/sub already publishes to a fanout exchange named after the topic
+		return broker.NewPublisher()
+	}
+
+	tracePropagatingPublisherDecorator := wotelfloss.NewTracePropagatingPublisherDecorator(publisher)
+	return wotel.NewNamedPublisherDecorator("pubsub.Publish", tracePropagatingPublisherDecorator), nil
+}
+
+// NewEventSubscriber creates a non-competing subscriber for fan-out events.
+// Each process gets its own queue, so every replica sees every event.
+func (broker *WatermillBrokerSettings) NewEventSubscriber() (message.Subscriber, error) {
+	wattermilLogger := watermill.NewSlogLogger(slog.Default())
+	var subscriber message.Subscriber
+
+	// Unique per process, so replicas never share (and compete for) a queue
+	instanceID, err := os.Hostname()
+	if err != nil || instanceID == "" {
+		instanceID = watermill.NewShortUUID()
+	}
+
+	switch broker.Kind {
+	case "sqs":
+		snsEndpointResolver, err := broker.AWS.GetSNSEndpointResolver()
+		if err != nil {
+			return nil, err
+		}
+		sqsEndpointResolver, err := broker.AWS.GetEndpointResolver()
+		if err != nil {
+			return nil, err
+		}
+		cfg, err := broker.AWS.NewAWSConfig()
+		if err != nil {
+			return nil, err
+		}
+
+		castedConfig := (cfg).(aws.Config)
+
+		snsOptFns := make([]func(*amazonsns.Options), 0)
+		sqsOptFns := make([]func(*amazonsqs.Options), 0)
+
+		if broker.AWS.SNSEndpoint != "" {
+			snsOptFns = append(snsOptFns, snsEndpointResolver)
+		}
+
+		if broker.AWS.Endpoint != "" {
+			sqsOptFns = append(sqsOptFns, sqsEndpointResolver)
+		}
+
+		topicResolver, err := sns.NewGenerateArnTopicResolver(broker.AWS.AccountID, broker.AWS.Region)
+		if err != nil {
+			return nil, err
+		}
+
+		generateQueueName := func(ctx context.Context, snsTopic sns.TopicArn) (string, error) {
+			topic, err := sns.ExtractTopicNameFromTopicArn(snsTopic)
+			if err != nil {
+				return "", err
+			}
+
+			return string(topic) + "-" + instanceID, nil
+		}
+
+		snsSubs, err := sns.NewSubscriber(
+			sns.SubscriberConfig{
+				AWSConfig:            castedConfig,
+				OptFns:               snsOptFns,
+				TopicResolver:        topicResolver,
+				GenerateSqsQueueName: generateQueueName,
+			},
+			sqs.SubscriberConfig{
+				OptFns:    sqsOptFns,
+				AWSConfig: castedConfig,
+				// Events only matter while someone listens, so a queue left behind by
+				// a process that crashed doesn't hold on to them
+				QueueConfigAttributes: sqs.QueueConfigAttributes{
+					MessageRetentionPeriod: eventQueueRetentionPeriod,
+				},
+			},
+			wattermilLogger,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		subscriber = &ephemeralSNSSubscriber{
+			Subscriber:        snsSubs,
+			sns:               amazonsns.NewFromConfig(castedConfig, snsOptFns...),
+			sqs:               amazonsqs.NewFromConfig(castedConfig, sqsOptFns...),
+			topicResolver:     topicResolver,
+			generateQueueName: generateQueueName,
+		}
+	case "amqp":
+		amqpConfig := amqp.NewDurablePubSubConfig(broker.AMQP.BuildURI(), amqp.GenerateQueueNameTopicNameWithSuffix(instanceID))
+		// The queue only lives as long as this process is connected
+		amqpConfig.Queue.Durable = false
+		amqpConfig.Queue.AutoDelete = true
+		amqpConfig.Queue.Exclusive = true
+
+		amqpSubscriber, err := amqp.NewSubscriber(amqpConfig, wattermilLogger)
+		if err != nil {
+			return nil, err
+		}
+		subscriber = amqpSubscriber
+	}
+
+	return subscriber, nil
+}
+
+// eventQueueRetentionPeriod is the shortest time in seconds SQS keeps a message for
+const eventQueueRetentionPeriod = "60"
+
+// ephemeralSNSSubscriber removes the SQS queue of this process and its SNS subscription when it is closed.
+// Queues are named after the hostname, which changes with every rollout, so queues left behind would
+// pile up and keep receiving every event.
+type ephemeralSNSSubscriber struct {
+	*sns.Subscriber
+
+	sns               *amazonsns.Client
+	sqs               *amazonsqs.Client
+	topicResolver     sns.TopicResolver
+	generateQueueName sns.GenerateSqsQueueNameFn
+
+	mu     sync.Mutex
+	topics []string
+}
+
+func (s *ephemeralSNSSubscriber) Subscribe(ctx context.Context, topic string) (<-chan *message.Message, error) {
+	// Recorded up front, the queue may exist even when subscribing fails halfway
+	s.mu.Lock()
+	if !slices.Contains(s.topics, topic) {
+		s.topics = append(s.topics, topic)
+	}
+	s.mu.Unlock()
+
+	return s.Subscriber.Subscribe(ctx, topic)
+}
+
+// Close stops consuming, then unsubscribes and deletes the queue of every subscribed topic
+func (s *ephemeralSNSSubscriber) Close() error {
+	err := s.Subscriber.Close()
+
+	// The context of the process is usually cancelled by the time it closes its subscriber
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	s.mu.Lock()
+	topics := slices.Clone(s.topics)
+	s.mu.Unlock()
+
+	for _, topic := range topics {
+		if removeErr := s.removeQueue(ctx, topic); removeErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to remove queue of topic %s: %w", topic, removeErr))
+		}
+	}
+
+	return err
+}
+
+func (s *ephemeralSNSSubscriber) removeQueue(ctx context.Context, topic string) error {
+	topicArn, err := s.topicResolver.ResolveTopic(ctx, topic)
+	if err != nil {
+		return err
+	}
+
+	queueName, err := s.generateQueueName(ctx, topicArn)
+	if err != nil {
+		return err
+	}
+
+	queueURL, err := s.sqs.GetQueueUrl(ctx, &amazonsqs.GetQueueUrlInput{QueueName: aws.String(queueName)})
+	if err != nil {
+		return fmt.Errorf("failed to get queue url: %w", err)
+	}
+
+	attributes, err := s.sqs.GetQueueAttributes(ctx, &amazonsqs.GetQueueAttributesInput{
+		QueueUrl:       queueURL.QueueUrl,
+		AttributeNames: []sqsTypes.QueueAttributeName{sqsTypes.QueueAttributeNameQueueArn},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get queue arn: %w", err)
+	}
+	queueArn := attributes.Attributes[string(sqsTypes.QueueAttributeNameQueueArn)]
+
+	subscriptions := amazonsns.NewListSubscriptionsByTopicPaginator(s.sns, &amazonsns.ListSubscriptionsByTopicInput{
+		TopicArn: aws.String(string(topicArn)),
+	})
+	for subscriptions.HasMorePages() {
+		page, err := subscriptions.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list subscriptions: %w", err)
+		}
+
+		for _, subscription := range page.Subscriptions {
+			if aws.ToString(subscription.Endpoint) != queueArn {
+				continue
+			}
+
+			_, err := s.sns.Unsubscribe(ctx, &amazonsns.UnsubscribeInput{SubscriptionArn: subscription.SubscriptionArn})
+			if err != nil {
+				return fmt.Errorf("failed to unsubscribe: %w", err)
+			}
+		}
+	}
+
+	_, err = s.sqs.DeleteQueue(ctx, &amazonsqs.DeleteQueueInput{QueueUrl: queueURL.QueueUrl})
+	if err != nil {
+		return fmt.Errorf("failed to delete queue: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Removed event queue", slog.String("queue", queueName))
+	return nil
+}
+
 type WatermillSettings struct {
-	Broker     WatermillBrokerSettings `mapstructure:"broker" validate:"required"`
-	ImageTopic string                  `mapstructure:"image-topic" validate:"required"`
+	Broker           WatermillBrokerSettings `mapstructure:"broker" validate:"required"`
+	ImageTopic       string                  `mapstructure:"image-topic" validate:"required"`
+	ImageStatusTopic string                  `mapstructure:"image-status-topic" validate:"required"`
 }
 
 type DynamoDBSettings struct {
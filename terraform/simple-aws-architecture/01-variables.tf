@@ -141,6 +141,12 @@ variable "db_password" {
   sensitive   = true
 }
 
+variable "render_signing_key" {
+  description = "HMAC key render URLs are signed with (at least 32 characters)"
+  type        = string
+  sensitive   = true
+}
+
 variable "db_name" {
   description = "Database name"
   type        = string
@@ -1,3 +1,6 @@
+# Account ID used by the apps to build SNS topic ARNs
+data "aws_caller_identity" "current" {}
+
 # Launch Template for Application Servers
 resource "aws_launch_template" "app" {
   name_prefix   = "${var.project_name}-app-lt-${var.environment}"
@@ -12,6 +15,7 @@ resource "aws_launch_template" "app" {
 
   user_data = base64encode(templatefile("${path.module}/user_data_app.sh", {
     AWS_REGION              = var.aws_region
+    AWS_ACCOUNT_ID          = data.aws_caller_identity.current.account_id
     DB_HOST                 = aws_db_instance.main.address
     DB_PORT                 = aws_db_instance.main.port
     DB_USERNAME             = var.db_username
@@ -28,6 +32,7 @@ resource "aws_launch_template" "app" {
     ALB_DNS_NAME            = aws_lb.app.dns_name
     AWS_BUCKET_ENDPOINT     = "https://${aws_s3_bucket.images.bucket}.s3.${var.aws_region}.amazonaws.com"
     DYNAMODB_TABLE          = aws_dynamodb_table.main.name
+    RENDER_SIGNING_KEY      = var.render_signing_key
   }))
 
   update_default_version = true
@@ -48,7 +48,10 @@ services:
       WORKER_OBJECTSTORER_SECRETACCESSKEY: ""
       WORKER_WATERMILL_BROKER_AWS_ENDPOINT: "https://sqs.${AWS_REGION}.amazonaws.com"
       WORKER_WATERMILL_BROKER_AWS_ANONYMOUS: false
+      WORKER_WATERMILL_BROKER_AWS_ACCOUNTID: "${AWS_ACCOUNT_ID}"
+      WORKER_WATERMILL_BROKER_AWS_SNSENDPOINT: ""
       WORKER_IMAGEPROCESSOR_BUCKETNAME: ${S3_BUCKET_NAME}
+      WORKER_IMAGEPROCESSOR_RENDERSIGNINGKEY: "${RENDER_SIGNING_KEY}"
       AWS_REGION: ${AWS_REGION}
       WORKER_OPENTELEMETRY_ENABLED: true
       WORKER_OPENTELEMETRY_ENDPOINT: ${OTEL_COLLECTOR_ENDPOINT}
@@ -86,8 +89,11 @@ services:
       API_OBJECTSTORER_ACCESSKEYID: ""
       API_OBJECTSTORER_SECRETACCESSKEY: ""
       API_IMAGEPROCESSOR_BUCKETNAME: ${S3_BUCKET_NAME}
+      API_IMAGEPROCESSOR_RENDERSIGNINGKEY: "${RENDER_SIGNING_KEY}"
       API_WATERMILL_BROKER_AWS_ENDPOINT: "https://sqs.${AWS_REGION}.amazonaws.com"
       API_WATERMILL_BROKER_AWS_ANONYMOUS: false
+      API_WATERMILL_BROKER_AWS_ACCOUNTID: "${AWS_ACCOUNT_ID}"
+      API_WATERMILL_BROKER_AWS_SNSENDPOINT: ""
       AWS_REGION: ${AWS_REGION}
       API_OPENTELEMETRY_ENABLED: true
       API_OPENTELEMETRY_ENDPOINT: ${OTEL_COLLECTOR_ENDPOINT}